@@ -0,0 +1,318 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Server 文章查询HTTP服务
+type Server struct {
+	reader storage.Reader
+	mux    *http.ServeMux
+}
+
+// NewServer 创建查询服务
+func NewServer(reader storage.Reader) *Server {
+	s := &Server{
+		reader: reader,
+		mux:    http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/api/health", s.handleHealth)
+	s.mux.HandleFunc("/api/articles", s.handleArticles)
+	s.mux.HandleFunc("/api/articles/lookup", s.handleLookup)
+	s.mux.HandleFunc("/api/aggregates", s.handleAggregates)
+
+	return s
+}
+
+// Handler 返回HTTP处理器
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListResponse 文章列表响应
+type ListResponse struct {
+	Articles   []*models.Article `json:"articles"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// Bucket 聚合结果中的一个分组
+type Bucket struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// AggregateResponse 聚合响应
+type AggregateResponse struct {
+	By      string   `json:"by"`
+	Total   int      `json:"total"`
+	Buckets []Bucket `json:"buckets"`
+}
+
+// handleHealth 健康检查
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleArticles 按条件分页查询文章
+// GET /api/articles?from=2025-01-01&to=2025-01-31&edition=第1版&type=要闻&column=人民时评&q=关键词&limit=20&cursor=...
+func (s *Server) handleArticles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "只支持GET请求")
+		return
+	}
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := defaultPageSize
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			writeError(w, http.StatusBadRequest, "limit必须是正整数")
+			return
+		}
+		if limit > maxPageSize {
+			limit = maxPageSize
+		}
+	}
+
+	if value := r.URL.Query().Get("cursor"); value != "" {
+		cursor, err := decodeCursor(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.After = cursor
+	}
+
+	// 多取一条用于判断是否还有下一页
+	filter.Limit = limit + 1
+	articles := make([]*models.Article, 0, filter.Limit)
+	err = s.reader.ForEach(filter, func(article *models.Article) error {
+		articles = append(articles, article)
+		return nil
+	})
+	if err != nil {
+		log.Printf("查询文章失败: %v", err)
+		writeError(w, http.StatusInternalServerError, "查询文章失败")
+		return
+	}
+
+	response := ListResponse{Articles: articles}
+	if len(articles) > limit {
+		response.Articles = articles[:limit]
+		last := articles[limit-1]
+		response.NextCursor = encodeCursor(&storage.Cursor{PublishDate: last.PublishDate, ID: last.ID})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleLookup 查询单篇文章
+// GET /api/articles/lookup?url=... 或 ?id=...
+func (s *Server) handleLookup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "只支持GET请求")
+		return
+	}
+
+	filter := storage.ArticleFilter{URL: r.URL.Query().Get("url"), Limit: 1}
+	if value := r.URL.Query().Get("id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "id参数格式错误")
+			return
+		}
+		filter.ID = id
+	}
+	if filter.URL == "" && filter.ID == 0 {
+		writeError(w, http.StatusBadRequest, "缺少url或id参数")
+		return
+	}
+
+	var found *models.Article
+	err := s.reader.ForEach(filter, func(article *models.Article) error {
+		found = article
+		return nil
+	})
+	if err != nil {
+		log.Printf("查询文章失败: %v", err)
+		writeError(w, http.StatusInternalServerError, "查询文章失败")
+		return
+	}
+	if found == nil {
+		writeError(w, http.StatusNotFound, "文章不存在")
+		return
+	}
+
+	// 图片和表格单独存储时补充到文章中
+	if imageStore, ok := s.reader.(storage.ImageStore); ok && len(found.Images) == 0 {
+		if found.Images, err = imageStore.GetImages(found.ID); err != nil {
+			log.Printf("查询文章图片失败: %v", err)
+		}
+	}
+	if tableStore, ok := s.reader.(storage.TableStore); ok && len(found.Tables) == 0 {
+		if found.Tables, err = tableStore.GetTables(found.ID); err != nil {
+			log.Printf("查询文章表格失败: %v", err)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, found)
+}
+
+// handleAggregates 按年、月、版次、类型或栏目统计文章数量
+// GET /api/aggregates?by=year&from=1978-01-01&to=1992-12-31
+func (s *Server) handleAggregates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "只支持GET请求")
+		return
+	}
+
+	by := r.URL.Query().Get("by")
+	keyFunc, ok := aggregateKeys[by]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "by必须是year、month、edition、type或column之一")
+		return
+	}
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	counts := make(map[string]int)
+	total := 0
+	err = s.reader.ForEach(filter, func(article *models.Article) error {
+		counts[keyFunc(article)]++
+		total++
+		return nil
+	})
+	if err != nil {
+		log.Printf("统计文章失败: %v", err)
+		writeError(w, http.StatusInternalServerError, "统计文章失败")
+		return
+	}
+
+	buckets := make([]Bucket, 0, len(counts))
+	for key, count := range counts {
+		buckets = append(buckets, Bucket{Key: key, Count: count})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Key < buckets[j].Key
+	})
+
+	writeJSON(w, http.StatusOK, AggregateResponse{By: by, Total: total, Buckets: buckets})
+}
+
+// aggregateKeys 聚合维度到分组键的映射
+var aggregateKeys = map[string]func(article *models.Article) string{
+	"year": func(article *models.Article) string {
+		return article.PublishDate.Format("2006")
+	},
+	"month": func(article *models.Article) string {
+		return article.PublishDate.Format("2006-01")
+	},
+	"edition": func(article *models.Article) string {
+		return article.Edition
+	},
+	"type": func(article *models.Article) string {
+		return article.Type
+	},
+	"column": func(article *models.Article) string {
+		return article.Column
+	},
+}
+
+// parseFilter 从查询参数解析过滤条件
+func parseFilter(r *http.Request) (storage.ArticleFilter, error) {
+	query := r.URL.Query()
+	filter := storage.ArticleFilter{
+		Edition: query.Get("edition"),
+		Type:    query.Get("type"),
+		Column:  query.Get("column"),
+		Keyword: strings.TrimSpace(query.Get("q")),
+	}
+
+	var err error
+	if value := query.Get("from"); value != "" {
+		if filter.From, err = time.Parse("2006-01-02", value); err != nil {
+			return filter, fmt.Errorf("from日期格式错误，应为YYYY-MM-DD")
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if filter.To, err = time.Parse("2006-01-02", value); err != nil {
+			return filter, fmt.Errorf("to日期格式错误，应为YYYY-MM-DD")
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return filter, fmt.Errorf("from不能晚于to")
+	}
+
+	return filter, nil
+}
+
+// encodeCursor 将游标编码为不透明字符串
+func encodeCursor(cursor *storage.Cursor) string {
+	raw := cursor.PublishDate.Format(time.RFC3339) + "|" + strconv.FormatInt(cursor.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor 解析游标字符串
+func decodeCursor(value string) (*storage.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("cursor无效")
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("cursor无效")
+	}
+
+	publishDate, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return nil, errors.New("cursor无效")
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("cursor无效")
+	}
+
+	return &storage.Cursor{PublishDate: publishDate, ID: id}, nil
+}
+
+// writeJSON 写入JSON响应
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("写入响应失败: %v", err)
+	}
+}
+
+// writeError 写入错误响应
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
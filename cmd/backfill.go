@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/pipeline"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillSource string
+	backfillFields []string
+	backfillForce  bool
+	backfillFrom   string
+	backfillTo     string
+	backfillBatch  int
+)
+
+// backfillCmd represents the backfill command
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "为已存储的文章补充摘要、关键词和栏目",
+	Long: `为已存储的文章补充摘要、关键词和栏目
+
+新抓取的文章在保存前生成摘要、关键词并识别栏目，该命令为之前存储的文章补充这些字段。
+默认只处理字段为空的文章，使用--force重新生成全部。
+
+示例：
+  data-people backfill
+  data-people backfill --fields summary --from 2025-01 --to 2025-03
+  data-people backfill --fields column
+  data-people backfill --source mysql --force`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runBackfill()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backfillCmd)
+
+	backfillCmd.Flags().StringVar(&backfillSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+	backfillCmd.Flags().StringSliceVar(&backfillFields, "fields", []string{"summary", "keywords", "column"}, "需要补充的字段 (summary, keywords, column)")
+	backfillCmd.Flags().BoolVar(&backfillForce, "force", false, "重新生成已有值的字段")
+	backfillCmd.Flags().StringVar(&backfillFrom, "from", "", "发布日期下限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	backfillCmd.Flags().StringVar(&backfillTo, "to", "", "发布日期上限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	backfillCmd.Flags().IntVar(&backfillBatch, "batch", 1000, "每批写回的文章数")
+}
+
+func runBackfill() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	var filter storage.ArticleFilter
+	if filter.From, err = parseDateBound(backfillFrom, false); err != nil {
+		log.Fatalf("解析--from失败: %v", err)
+	}
+	if filter.To, err = parseDateBound(backfillTo, true); err != nil {
+		log.Fatalf("解析--to失败: %v", err)
+	}
+
+	extractor, err := pipeline.NewExtractor(cfg.Keywords)
+	if err != nil {
+		log.Fatalf("创建关键词提取器失败: %v", err)
+	}
+
+	var (
+		keywordTagger *pipeline.KeywordTagger
+		summaryTagger *pipeline.SummaryTagger
+		columnTagger  *pipeline.ColumnTagger
+	)
+	for _, field := range backfillFields {
+		switch strings.TrimSpace(field) {
+		case "keywords":
+			if keywordTagger, err = pipeline.NewKeywordTagger(cfg.Keywords, extractor); err != nil {
+				log.Fatalf("创建关键词提取失败: %v", err)
+			}
+		case "summary":
+			summaryTagger = pipeline.NewSummaryTagger(cfg.Summary, extractor)
+		case "column":
+			if columnTagger, err = pipeline.NewColumnTagger(cfg.Columns); err != nil {
+				log.Fatalf("创建栏目识别失败: %v", err)
+			}
+		default:
+			log.Fatalf("不支持的字段: %s", field)
+		}
+	}
+
+	reader, closeReader, err := openReader(cfg, backfillSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	updater, ok := reader.(storage.ArticleUpdater)
+	if !ok {
+		log.Fatalf("数据来源不支持更新文章")
+	}
+
+	var (
+		batch   []*models.Article
+		scanned int
+		updated int
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := updater.UpdateDerived(batch); err != nil {
+			return err
+		}
+		updated += len(batch)
+		fmt.Printf("已更新 %d 篇文章 (已扫描 %d 篇)\n", updated, scanned)
+		batch = batch[:0]
+		return nil
+	}
+
+	err = reader.ForEach(filter, func(article *models.Article) error {
+		scanned++
+
+		changed := false
+		if keywordTagger != nil && (backfillForce || article.Keywords == "") {
+			article.Keywords = ""
+			keywordTagger.Tag(article)
+			changed = true
+		}
+		if summaryTagger != nil && (backfillForce || article.Summary == "") {
+			article.Summary = ""
+			summaryTagger.Tag(article)
+			changed = true
+		}
+		if columnTagger != nil && (backfillForce || article.Column == "") {
+			article.Column = ""
+			columnTagger.Tag(article)
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+
+		batch = append(batch, article)
+		if len(batch) >= backfillBatch {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		log.Fatalf("回填失败: %v", err)
+	}
+
+	fmt.Printf("✓ 回填完成: 扫描 %d 篇, 更新 %d 篇\n", scanned, updated)
+}
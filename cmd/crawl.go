@@ -1,26 +1,46 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/Lan-ce-lot/data-people/config"
 	"github.com/Lan-ce-lot/data-people/crawler"
 	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/pipeline"
+	"github.com/Lan-ce-lot/data-people/search"
 	"github.com/Lan-ce-lot/data-people/storage"
 	"github.com/Lan-ce-lot/data-people/utils"
 	"github.com/spf13/cobra"
 )
 
+// 抓取方式
+const (
+	crawlModeSearch = "search" // 按检索结果分页抓取
+	crawlModeLayout = "layout" // 按电子版日期、版面抓取
+)
+
 var (
 	startDate string
 	endDate   string
 	workers   int
+	planFile  string
+	refetch   bool
+	shard     string
+
+	dryRun         bool
+	dryRunProbe    bool
+	articlesPerDay int
+	dryRunURLs     bool
 )
 
 // crawlCmd represents the crawl command
@@ -36,7 +56,11 @@ var crawlCmd = &cobra.Command{
 示例：
   data-people crawl --config config.yaml
   data-people crawl --start-date 2025-01-01 --end-date 2025-01-31
-  data-people crawl --workers 10`,
+  data-people crawl --workers 10
+  data-people crawl --plan gaps_plan.json
+  data-people crawl --refetch --start-date 2025-01-01 --end-date 2025-01-31
+  data-people crawl --shard 2/3 --start-date 2000-01-01 --end-date 2025-12-31
+  data-people crawl --dry-run --probe --start-date 2025-01-01 --end-date 2025-03-31`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runCrawler(cmd, args)
 	},
@@ -49,6 +73,13 @@ func init() {
 	crawlCmd.Flags().StringVar(&startDate, "start-date", "", "开始日期 (YYYY-MM-DD)")
 	crawlCmd.Flags().StringVar(&endDate, "end-date", "", "结束日期 (YYYY-MM-DD)")
 	crawlCmd.Flags().IntVar(&workers, "workers", 0, "并发worker数量 (0表示使用配置文件设置)")
+	crawlCmd.Flags().StringVar(&planFile, "plan", "", "补抓计划文件 (由 gaps 命令生成)，指定后忽略日期范围设置")
+	crawlCmd.Flags().BoolVar(&refetch, "refetch", false, "重新抓取已存储的文章（用于检测修订），不跳过已存储的文章和时间段")
+	crawlCmd.Flags().BoolVar(&dryRun, "dry-run", false, "只显示各时间段的请求URL并估算请求数和耗时，不抓取")
+	crawlCmd.Flags().BoolVar(&dryRunProbe, "probe", false, "dry run时每个时间段发出一次检索请求，获取文章总数 (search方式)")
+	crawlCmd.Flags().IntVar(&articlesPerDay, "articles-per-day", 0, "dry run时按每天的文章数估算 (文章总数未知时使用)")
+	crawlCmd.Flags().BoolVar(&dryRunURLs, "urls", false, "dry run时显示全部请求URL (默认每个时间段只显示第一个)")
+	crawlCmd.Flags().StringVar(&shard, "shard", "", "只抓取第i个分片的时间段 (i/n，i从1开始)，各台机器使用相同配置分别抓取，之后用 merge 命令合并输出")
 }
 
 func runCrawler(_ *cobra.Command, _ []string) {
@@ -68,101 +99,275 @@ func runCrawler(_ *cobra.Command, _ []string) {
 	if workers > 0 {
 		cfg.Crawler.Workers = workers
 	}
-
-	fmt.Printf("=== %s v%s ===\n", cfg.App.Name, cfg.App.Version)
-	fmt.Printf("配置文件: %s\n", configFile)
-	fmt.Printf("并发worker数: %d\n", cfg.Crawler.Workers)
-	fmt.Printf("请求间隔: %v\n", cfg.Crawler.RequestInterval)
-	fmt.Printf("存储类型: %v\n", cfg.Storage.Types)
-	if cfg.DateRange.StartDate != "" && cfg.DateRange.EndDate != "" {
-		fmt.Printf("日期范围: %s 到 %s\n", cfg.DateRange.StartDate, cfg.DateRange.EndDate)
-	} else {
-		fmt.Printf("年份范围: %d 到 %d\n", cfg.DateRange.StartYear, cfg.DateRange.EndYear)
+	if refetch {
+		cfg.Crawler.SkipExisting = false
+	}
+	// 补抓计划中的时间段本来就不完整，已存储的文章可能排在前面，不能提前结束
+	if planFile != "" {
+		cfg.Crawler.StopAfterKnownPages = 0
 	}
-	fmt.Println()
 
-	// 创建存储实例
-	storages, err := createStorages(cfg)
+	dateRanges, err := crawlDateRanges(cfg, planFile)
 	if err != nil {
-		log.Fatalf("创建存储实例失败: %v", err)
+		log.Fatalf("生成抓取任务失败: %v", err)
 	}
-	defer closeStorages(storages)
-
-	// 初始化存储
-	for _, store := range storages {
-		if err := store.Init(); err != nil {
-			log.Fatalf("初始化%s存储失败: %v", store.GetStorageType(), err)
+	if shard != "" {
+		s, err := utils.ParseShard(shard)
+		if err != nil {
+			log.Fatalf("解析分片失败: %v", err)
 		}
-		fmt.Printf("✓ %s存储初始化成功\n", store.GetStorageType())
+		total := len(dateRanges)
+		dateRanges = s.Select(dateRanges)
+		fmt.Printf("分片 %s: 抓取 %d 个时间段中的 %d 个\n", s, total, len(dateRanges))
 	}
 
-	// 创建HTTP客户端
-	httpClient := crawler.NewHTTPClient(cfg.Crawler.Timeout, cfg.Crawler.UserAgent, cfg.Crawler.BaseCookies)
+	if dryRun {
+		runDryRun(cfg, dateRanges, dryRunProbe, articlesPerDay, dryRunURLs)
+		return
+	}
 
-	// 创建数据解析器
-	parser := crawler.NewParser(httpClient)
+	// 设置信号处理
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// 创建URL构建器
-	urlBuilder := utils.NewURLBuilder(cfg.Crawler.BaseSearchURL)
+	if _, err := executeCrawl(cfg, dateRanges, signalChan); err != nil {
+		log.Fatalf("抓取失败: %v", err)
+	}
+}
 
-	// 生成日期范围任务
-	var dateRanges []utils.DateRange
+// executeCrawl 持有抓取锁，初始化存储和处理流程后抓取各时间段，结束或收到中断信号后显示统计并记录运行
+// 被中断时运行状态为interrupted，调用方应随后退出进程
+func executeCrawl(cfg *config.Config, dateRanges []utils.DateRange, interrupt <-chan os.Signal) (*models.CrawlRun, error) {
+	// 同一时间只允许一个抓取，避免重复请求和写入冲突
+	lock, err := utils.TryLock(cfg.Schedule.LockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			log.Printf("释放抓取锁失败: %v", err)
+		}
+	}()
 
-	// 检查是否设置了具体日期范围
+	showCrawlHeader(cfg)
 	if cfg.DateRange.StartDate != "" && cfg.DateRange.EndDate != "" {
-		var parseErr error
-		dateRanges, parseErr = urlBuilder.ParseSpecificDateRange(cfg.DateRange.StartDate, cfg.DateRange.EndDate)
-		if parseErr != nil {
-			log.Fatalf("解析具体日期范围失败: %v", parseErr)
-		}
-		fmt.Printf("生成了 %d 个任务 (%s 到 %s)\n",
-			len(dateRanges), cfg.DateRange.StartDate, cfg.DateRange.EndDate)
+		fmt.Printf("日期范围: %s 到 %s\n", cfg.DateRange.StartDate, cfg.DateRange.EndDate)
 	} else {
-		dateRanges = urlBuilder.ParseDateRange(cfg.DateRange.StartYear, cfg.DateRange.EndYear)
-		fmt.Printf("生成了 %d 个月份任务 (%d年-%d年)\n",
-			len(dateRanges), cfg.DateRange.StartYear, cfg.DateRange.EndYear)
+		fmt.Printf("年份范围: %d 到 %d\n", cfg.DateRange.StartYear, cfg.DateRange.EndYear)
 	}
+	fmt.Println()
+
+	env, err := newCrawlEnv(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer env.Close()
 
 	// 创建统计信息
 	stats := &models.CrawlerStats{
-		TotalTasks: len(dateRanges),
-		StartTime:  time.Now(),
+		TotalTasks:     len(dateRanges),
+		StartTime:      time.Now(),
+		ReportedTotals: make(map[string]int),
 	}
-
-	// 设置信号处理
-	signalChan := make(chan os.Signal, 1)
 	doneChan := make(chan bool, 1)
-	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
 	// 启动爬虫
 	fmt.Println("开始抓取数据...")
-	go runCrawlerWorker(cfg, httpClient, parser, urlBuilder, storages, dateRanges, stats, doneChan)
+	go runCrawlerWorker(env, dateRanges, stats, doneChan)
 
 	// 等待完成或中断信号
+	status := models.RunStatusCompleted
 	select {
 	case <-doneChan:
 		fmt.Println("\n✓ 抓取任务完成")
-	case <-signalChan:
+	case <-interrupt:
 		fmt.Println("\n收到中断信号，正在优雅关闭...")
+		status = models.RunStatusInterrupted
+		// 等待当前请求结束，之后统计不再变化，存储也不再写入
+		env.Stop()
+		fmt.Println("等待当前请求结束...")
+		<-doneChan
 	}
 
 	// 显示最终统计
 	showFinalStats(stats)
+
+	// 记录本次运行
+	return recordRun(cfg, env.storages, dateRanges, stats, status), nil
+}
+
+// crawlDateRanges 生成抓取任务：优先使用补抓计划，其次是配置的具体日期范围，最后按年份范围逐月生成
+func crawlDateRanges(cfg *config.Config, plan string) ([]utils.DateRange, error) {
+	urlBuilder := utils.NewURLBuilder(cfg.Crawler.BaseSearchURL, cfg.Crawler.BaseLayoutURL)
+
+	if plan != "" {
+		dateRanges, err := utils.LoadPlan(plan)
+		if err != nil {
+			return nil, fmt.Errorf("加载补抓计划失败: %v", err)
+		}
+		fmt.Printf("从补抓计划生成了 %d 个任务 (%s)\n", len(dateRanges), plan)
+		return dateRanges, nil
+	}
+
+	if cfg.DateRange.StartDate != "" && cfg.DateRange.EndDate != "" {
+		dateRanges, err := urlBuilder.ParseSpecificDateRange(cfg.DateRange.StartDate, cfg.DateRange.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("解析具体日期范围失败: %v", err)
+		}
+		fmt.Printf("生成了 %d 个任务 (%s 到 %s)\n",
+			len(dateRanges), cfg.DateRange.StartDate, cfg.DateRange.EndDate)
+		return dateRanges, nil
+	}
+
+	dateRanges := urlBuilder.ParseDateRange(cfg.DateRange.StartYear, cfg.DateRange.EndYear)
+	fmt.Printf("生成了 %d 个月份任务 (%d年-%d年)\n",
+		len(dateRanges), cfg.DateRange.StartYear, cfg.DateRange.EndYear)
+	return dateRanges, nil
+}
+
+// showCrawlHeader 显示抓取配置
+func showCrawlHeader(cfg *config.Config) {
+	fmt.Printf("=== %s v%s ===\n", cfg.App.Name, cfg.App.Version)
+	fmt.Printf("配置文件: %s\n", configFile)
+	fmt.Printf("抓取方式: %s\n", cfg.Crawler.Mode)
+	fmt.Printf("并发worker数: %d\n", cfg.Crawler.Workers)
+	fmt.Printf("请求间隔: %v\n", cfg.Crawler.RequestInterval)
+	fmt.Printf("存储类型: %v\n", cfg.Storage.Types)
+	fmt.Printf("跳过已存储文章: %v\n", cfg.Crawler.SkipExisting)
+}
+
+// crawlEnv 抓取所需的存储、处理流程、HTTP客户端和解析器，多个时间段共用
+type crawlEnv struct {
+	cfg        *config.Config
+	storages   []storage.Storage
+	pipe       *pipeline.Pipeline
+	httpClient *crawler.HTTPClient
+	parser     *crawler.Parser
+	assets     *crawler.AssetDownloader
+	urlBuilder *utils.URLBuilder
+	windows    *storage.WindowLog // search方式抓取完的时间段，未配置时为nil
+
+	stop     chan struct{} // 关闭后在下一次请求前结束抓取
+	stopOnce sync.Once
+}
+
+// errCrawlStopped 抓取被停止，当前时间段没有抓完
+var errCrawlStopped = errors.New("抓取已停止")
+
+// newCrawlEnv 创建并初始化存储、处理流程和图片下载器，失败时关闭已创建的存储
+func newCrawlEnv(cfg *config.Config) (*crawlEnv, error) {
+	if cfg.Crawler.Mode != crawlModeSearch && cfg.Crawler.Mode != crawlModeLayout {
+		return nil, fmt.Errorf("不支持的抓取方式: %s (支持 search、layout)", cfg.Crawler.Mode)
+	}
+
+	// 创建存储实例
+	storages, err := createStorages(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("创建存储实例失败: %v", err)
+	}
+	env := &crawlEnv{cfg: cfg, storages: storages, stop: make(chan struct{})}
+
+	// 初始化存储
+	for _, store := range storages {
+		if err := store.Init(); err != nil {
+			env.Close()
+			return nil, fmt.Errorf("初始化%s存储失败: %v", store.GetStorageType(), err)
+		}
+		fmt.Printf("✓ %s存储初始化成功\n", store.GetStorageType())
+	}
+
+	// 创建处理流程（文本规范化、数据校验与隔离）
+	if env.pipe, err = pipeline.New(cfg); err != nil {
+		env.Close()
+		return nil, fmt.Errorf("创建处理流程失败: %v", err)
+	}
+	if err := env.pipe.Init(); err != nil {
+		env.Close()
+		return nil, fmt.Errorf("初始化处理流程失败: %v", err)
+	}
+
+	// 创建HTTP客户端和数据解析器
+	env.httpClient = crawler.NewHTTPClient(cfg.Crawler.Timeout, cfg.Crawler.UserAgent, cfg.Crawler.BaseCookies)
+	env.parser = crawler.NewParser(env.httpClient)
+
+	// 创建图片下载器，与文章请求使用相同的请求间隔
+	if cfg.Assets.Enabled {
+		env.assets = crawler.NewAssetDownloader(env.httpClient, cfg.Assets.Dir, cfg.Crawler.MaxRetries, cfg.Crawler.RequestInterval, func() {
+			sleepWithRandomDelay(cfg.Crawler.RequestInterval)
+		})
+		if err := env.assets.Init(); err != nil {
+			env.Close()
+			return nil, fmt.Errorf("初始化图片下载失败: %v", err)
+		}
+	}
+
+	// 创建URL构建器
+	env.urlBuilder = utils.NewURLBuilder(cfg.Crawler.BaseSearchURL, cfg.Crawler.BaseLayoutURL)
+	if cfg.Storage.WindowLog != "" {
+		env.windows = storage.NewWindowLog(cfg.Storage.WindowLog)
+	}
+	return env, nil
+}
+
+// Close 关闭所有存储，调用前应等待正在进行的抓取结束
+func (e *crawlEnv) Close() {
+	closeStorages(e.storages)
+}
+
+// Stop 通知正在进行的抓取在下一次请求前结束，可以重复调用
+func (e *crawlEnv) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stop)
+	})
+}
+
+// pause 按请求间隔（带随机延迟）等待，抓取被停止时立即返回false
+func (e *crawlEnv) pause() bool {
+	select {
+	case <-e.stop:
+		return false
+	case <-time.After(randomDelay(e.cfg.Crawler.RequestInterval)):
+		return true
+	}
+}
+
+// stopped 抓取是否已被停止
+func (e *crawlEnv) stopped() bool {
+	select {
+	case <-e.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// crawlRange 按配置的抓取方式抓取一个时间段，被停止时返回errCrawlStopped
+func (e *crawlEnv) crawlRange(dateRange utils.DateRange, stats *models.CrawlerStats) error {
+	if e.cfg.Crawler.Mode == crawlModeLayout {
+		return e.crawlLayoutRange(dateRange, stats)
+	}
+	return e.crawlDateRange(dateRange, stats)
 }
 
 // runCrawlerWorker 运行爬虫工作程序
-func runCrawlerWorker(cfg *config.Config, httpClient *crawler.HTTPClient, parser *crawler.Parser,
-	urlBuilder *utils.URLBuilder, storages []storage.Storage,
-	dateRanges []utils.DateRange, stats *models.CrawlerStats, doneChan chan bool) {
+func runCrawlerWorker(env *crawlEnv, dateRanges []utils.DateRange, stats *models.CrawlerStats, doneChan chan bool) {
 
 	defer func() {
 		doneChan <- true
 	}()
 
 	for i, dateRange := range dateRanges {
+		if env.stopped() {
+			break
+		}
 		fmt.Printf("[%d/%d] 处理时间段: %s\n", i+1, len(dateRanges), dateRange.String())
 
-		if err := crawlDateRange(cfg, httpClient, parser, urlBuilder, storages, dateRange, stats); err != nil {
+		err := env.crawlRange(dateRange, stats)
+		if err == errCrawlStopped {
+			log.Printf("抓取已停止，时间段未完成 [%s]", dateRange.String())
+			break
+		}
+		if err != nil {
 			log.Printf("处理时间段失败 [%s]: %v", dateRange.String(), err)
 			stats.FailedTasks++
 		} else {
@@ -171,7 +376,9 @@ func runCrawlerWorker(cfg *config.Config, httpClient *crawler.HTTPClient, parser
 		}
 
 		// 请求间隔（带随机延迟）
-		sleepWithRandomDelay(cfg.Crawler.RequestInterval)
+		if !env.pause() {
+			break
+		}
 	}
 
 	stats.Duration = time.Since(stats.StartTime)
@@ -181,19 +388,27 @@ func runCrawlerWorker(cfg *config.Config, httpClient *crawler.HTTPClient, parser
 }
 
 // crawlDateRange 抓取指定日期范围的数据
-func crawlDateRange(cfg *config.Config, httpClient *crawler.HTTPClient, parser *crawler.Parser,
-	urlBuilder *utils.URLBuilder, storages []storage.Storage,
-	dateRange utils.DateRange, stats *models.CrawlerStats) error {
+func (e *crawlEnv) crawlDateRange(dateRange utils.DateRange, stats *models.CrawlerStats) error {
+	cfg, httpClient, parser, urlBuilder := e.cfg, e.httpClient, e.parser, e.urlBuilder
 
 	pageNo := 1
 	hasMore := true
+	knownPages := 0 // 连续的文章都已存储的页数
+	earlyStop := cfg.Crawler.SkipExisting && cfg.Crawler.StopAfterKnownPages > 0
+	reported := 0                // 网站报告的时间段总数，未知时为0
+	seen := make(map[int64]bool) // 本次检索结果中文章的规范ID
 
 	for hasMore {
 		fmt.Printf("  处理第 %d 页\n", pageNo)
 		pageHasResults := false
+		pageKnown := true
 
 		// 内循环：遍历当前页的所有position (0-19)
 		for position := 0; position < 20; position++ {
+			if e.stopped() {
+				return errCrawlStopped
+			}
+
 			// 构建搜索URL
 			searchURL, err := urlBuilder.BuildSearchURL(dateRange.Start, dateRange.End, pageNo, position)
 			if err != nil {
@@ -224,6 +439,17 @@ func crawlDateRange(cfg *config.Config, httpClient *crawler.HTTPClient, parser *
 
 			pageHasResults = true
 
+			// 记录网站报告的时间段总数，上次记录的文章都已存储时不再继续请求
+			if pageNo == 1 && position == 0 && response.Data.Total > 0 {
+				reported = response.Data.Total
+				stats.ReportedTotals[dateRange.Key()] = reported
+				if earlyStop && e.windowStored(dateRange, reported, seen) {
+					fmt.Printf("  %d 篇文章均已存储，跳过当前时间段\n", reported)
+					stats.Skipped += reported
+					return nil
+				}
+			}
+
 			// 转换为指针切片
 			var articles []*models.Article
 			for i := range response.Data.Results {
@@ -232,36 +458,395 @@ func crawlDateRange(cfg *config.Config, httpClient *crawler.HTTPClient, parser *
 
 			log.Printf("    获取到 %d 篇文章 (position=%d)\n", len(articles), position)
 
-			// 保存到各个存储
-			for _, store := range storages {
-				if err := store.SaveBatch(articles); err != nil {
-					log.Printf("保存到%s失败: %v", store.GetStorageType(), err)
-				} else {
-					fmt.Printf("    ✓ 保存 %d 篇文章到%s (position=%d)\n", len(articles), store.GetStorageType(), position)
+			if e.windows != nil {
+				e.pipe.Identify(articles)
+				for _, article := range articles {
+					seen[article.ID] = true
 				}
 			}
 
-			// 更新统计
-			stats.TotalArticles += len(articles)
+			if articles = skipStoredArticles(cfg, e.pipe, e.storages, articles, dateRange, stats); len(articles) > 0 {
+				pageKnown = false
+			}
+			saveArticles(e.pipe, e.assets, e.storages, articles, dateRange, stats, fmt.Sprintf("position=%d", position))
 
 			// position间隔（带随机延迟）
-			sleepWithRandomDelay(cfg.Crawler.RequestInterval)
+			if !e.pause() {
+				return errCrawlStopped
+			}
 		}
 
 		// 如果当前页没有任何结果，说明没有更多数据了
 		if !pageHasResults {
 			hasMore = false
 		} else {
+			// 重新抓取已完成的时间段时，连续几页都已存储就不再继续翻页
+			if pageKnown {
+				knownPages++
+			} else {
+				knownPages = 0
+			}
+			// 检索结果按时间倒序，前几页已存储不代表后面也已存储，还要求时间段中的文章都已存储
+			if earlyStop && knownPages >= cfg.Crawler.StopAfterKnownPages &&
+				e.windowStored(dateRange, reported, seen) {
+				fmt.Printf("  连续 %d 页的文章都已存储且时间段中的文章均已存储，结束当前时间段\n", knownPages)
+				break
+			}
+
 			// 进入下一页
 			pageNo++
 			// 页面间隔（带随机延迟）
-			sleepWithRandomDelay(cfg.Crawler.RequestInterval)
+			if !e.pause() {
+				return errCrawlStopped
+			}
+		}
+	}
+
+	e.recordWindow(dateRange, reported, seen)
+	return nil
+}
+
+// crawlLayoutRange 按电子版抓取指定日期范围：逐日读取版面列表，再逐版抓取版面中的文章
+// 没有电子版的日期（如休刊）记录日志后跳过
+func (e *crawlEnv) crawlLayoutRange(dateRange utils.DateRange, stats *models.CrawlerStats) error {
+	cfg, httpClient, parser, urlBuilder, storages := e.cfg, e.httpClient, e.parser, e.urlBuilder, e.storages
+
+	days := dateRange.Days()
+	crawledDays := 0
+	reported := 0
+	requested := false
+
+	for _, day := range days {
+		if e.stopped() {
+			return errCrawlStopped
+		}
+
+		// 已保存的整期报纸中的文章都已存储时，当天不再发出请求
+		if count, ok := storedIssue(cfg, storages, day); ok {
+			fmt.Printf("  跳过 %s: %d 篇文章均已存储\n", day.Format("2006-01-02"), count)
+			stats.Skipped += count
+			reported += count
+			crawledDays++
+			continue
+		}
+
+		if requested && !e.pause() {
+			return errCrawlStopped
+		}
+		requested = true
+
+		firstURL := urlBuilder.BuildLayoutURL(day, 1)
+		fmt.Printf("  处理 %s: %s\n", day.Format("2006-01-02"), firstURL)
+
+		body, err := httpClient.GetWithRetry(firstURL, cfg.Crawler.MaxRetries, cfg.Crawler.RequestInterval)
+		if err != nil {
+			log.Printf("获取版面失败，跳过 %s: %v", day.Format("2006-01-02"), err)
+			continue
+		}
+		issue, first, err := parser.ParseLayoutPage(body, firstURL, day)
+		if err != nil {
+			log.Printf("解析版面失败，跳过 %s: %v", day.Format("2006-01-02"), err)
+			continue
+		}
+		crawledDays++
+
+		for i, page := range issue.Pages {
+			current := first
+			if page.Edition != first.Edition {
+				if !e.pause() {
+					return errCrawlStopped
+				}
+				body, err := httpClient.GetWithRetry(page.URL, cfg.Crawler.MaxRetries, cfg.Crawler.RequestInterval)
+				if err != nil {
+					log.Printf("获取版面失败 [%s]: %v", page.URL, err)
+					continue
+				}
+				if _, current, err = parser.ParseLayoutPage(body, page.URL, day); err != nil {
+					log.Printf("解析版面失败 [%s]: %v", page.URL, err)
+					continue
+				}
+			}
+			if current.Name == "" {
+				current.Name = page.Name
+			}
+			issue.Pages[i] = *current
+
+			fmt.Printf("    %s %s: %d 篇文章\n", current.EditionName(), current.Name, len(current.Articles))
+			reported += len(current.Articles)
+
+			// 版面链接中带有文档ID，已存储的文章不再请求
+			known := make(map[int64]bool)
+			if cfg.Crawler.SkipExisting {
+				known = knownArticles(storages, pageArticleIDs(current.Articles), utils.DateRange{Start: day, End: day})
+			}
+
+			var articles []*models.Article
+			skipped := 0
+			for _, link := range current.Articles {
+				if known[models.DocumentID(link.URL)] {
+					skipped++
+					continue
+				}
+				if !e.pause() {
+					// 已抓取的文章仍然保存，版面不保存，下次重新抓取当天
+					saveArticles(e.pipe, e.assets, storages, articles, dateRange, stats, fmt.Sprintf("%s %s", day.Format("2006-01-02"), current.EditionName()))
+					return errCrawlStopped
+				}
+				body, err := httpClient.GetWithRetry(link.URL, cfg.Crawler.MaxRetries, cfg.Crawler.RequestInterval)
+				if err != nil {
+					log.Printf("获取文章失败 [%s]: %v", link.URL, err)
+					continue
+				}
+				article, err := parser.ParseLayoutArticle(body, day, current, link)
+				if err != nil {
+					log.Printf("解析文章失败 [%s]: %v", link.URL, err)
+					continue
+				}
+				articles = append(articles, article)
+			}
+			if skipped > 0 {
+				fmt.Printf("    - 跳过 %d 篇已存储文章\n", skipped)
+				stats.Skipped += skipped
+			}
+
+			saveArticles(e.pipe, e.assets, storages, articles, dateRange, stats, fmt.Sprintf("%s %s", day.Format("2006-01-02"), current.EditionName()))
+		}
+
+		// 保存版面和文章在版面中的顺序，用于还原整期报纸
+		issue.CrawledAt = time.Now()
+		for _, store := range storages {
+			if issueStore, ok := store.(storage.IssueStore); ok {
+				if err := issueStore.SaveIssue(issue); err != nil {
+					log.Printf("保存版面到%s失败: %v", store.GetStorageType(), err)
+				}
+			}
 		}
 	}
 
+	// 版面中的文章数就是当天的完整篇数，供gaps命令对比
+	if reported > 0 {
+		stats.ReportedTotals[dateRange.Key()] = reported
+	}
+	if len(days) > 0 && crawledDays == 0 {
+		return fmt.Errorf("时间段内没有获取到任何版面")
+	}
 	return nil
 }
 
+// storedIssue 返回已保存的当天报纸的文章数，每个版面都有文章且文章在所有存储中都已存在时ok为true
+func storedIssue(cfg *config.Config, storages []storage.Storage, day time.Time) (int, bool) {
+	if !cfg.Crawler.SkipExisting {
+		return 0, false
+	}
+
+	for _, store := range storages {
+		issueStore, ok := store.(storage.IssueStore)
+		if !ok {
+			continue
+		}
+		issue, err := issueStore.GetIssue(day)
+		if err != nil {
+			continue
+		}
+
+		var ids []int64
+		for _, page := range issue.Pages {
+			// 获取失败的版面没有文章，需要重新抓取
+			if len(page.Articles) == 0 {
+				return 0, false
+			}
+			ids = append(ids, pageArticleIDs(page.Articles)...)
+		}
+		if len(ids) == 0 {
+			return 0, false
+		}
+
+		known := knownArticles(storages, ids, utils.DateRange{Start: day, End: day})
+		for _, id := range ids {
+			if !known[id] {
+				return 0, false
+			}
+		}
+		return len(ids), true
+	}
+	return 0, false
+}
+
+// pageArticleIDs 版面中文章链接的文档ID，无法从链接得到ID的文章不包含在内
+func pageArticleIDs(links []models.PageArticle) []int64 {
+	var ids []int64
+	for _, link := range links {
+		if id := models.DocumentID(link.URL); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// knownArticles 返回在所有存储中都已存在的规范ID，只在部分存储中存在的文章仍需抓取以补齐
+// 文章的发布日期在dateRange内，支持按范围查找的存储只加载范围内的数据
+// 查询失败时返回空集合，即全部重新抓取
+func knownArticles(storages []storage.Storage, ids []int64, dateRange utils.DateRange) map[int64]bool {
+	if len(ids) == 0 {
+		return make(map[int64]bool)
+	}
+
+	var known map[int64]bool
+	for _, store := range storages {
+		var found map[int64]bool
+		var err error
+		if checker, ok := store.(storage.RangeChecker); ok {
+			found, err = checker.HasInRange(ids, dateRange.Start, dateRange.End)
+		} else {
+			found, err = store.Has(ids)
+		}
+		if err != nil {
+			log.Printf("查询%s中已存储文章失败: %v", store.GetStorageType(), err)
+			return make(map[int64]bool)
+		}
+		if known == nil {
+			known = found
+			continue
+		}
+		for id := range known {
+			if !found[id] {
+				delete(known, id)
+			}
+		}
+	}
+	if known == nil {
+		known = make(map[int64]bool)
+	}
+	return known
+}
+
+// windowIDs 上次记录的时间段文章ID与本次检索到的ID的并集
+func (e *crawlEnv) windowIDs(dateRange utils.DateRange, seen map[int64]bool) []int64 {
+	ids := make(map[int64]bool, len(seen))
+	for id := range seen {
+		ids[id] = true
+	}
+	if window, err := e.windows.GetWindow(dateRange.Key()); err != nil {
+		log.Printf("读取时间段记录失败: %v", err)
+	} else if window != nil {
+		for _, id := range window.IDs {
+			ids[id] = true
+		}
+	}
+
+	result := make([]int64, 0, len(ids))
+	for id := range ids {
+		result = append(result, id)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// windowStored 判断时间段中的文章是否都已存储：已知的文章ID不少于网站报告的总数，且在所有存储中都已存在
+// 已知的文章ID来自上次抓取完该时间段时的记录和本次检索到的文章，没有报告总数或记录时无法确认，返回false
+func (e *crawlEnv) windowStored(dateRange utils.DateRange, reported int, seen map[int64]bool) bool {
+	if e.windows == nil || reported <= 0 {
+		return false
+	}
+
+	ids := e.windowIDs(dateRange, seen)
+	if len(ids) < reported {
+		return false
+	}
+	known := knownArticles(e.storages, ids, dateRange)
+	for _, id := range ids {
+		if !known[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// recordWindow 记录抓取完的时间段中文章的规范ID，供下次重新抓取时判断
+func (e *crawlEnv) recordWindow(dateRange utils.DateRange, reported int, seen map[int64]bool) {
+	if e.windows == nil || reported <= 0 {
+		return
+	}
+
+	window := &models.SearchWindow{
+		Key:       dateRange.Key(),
+		Total:     reported,
+		IDs:       e.windowIDs(dateRange, seen),
+		CrawledAt: time.Now(),
+	}
+	if err := e.windows.SaveWindow(window); err != nil {
+		log.Printf("保存时间段记录失败: %v", err)
+	}
+}
+
+// skipStoredArticles 计算规范ID并去掉所有存储中都已有的文章，文章的发布日期在dateRange内
+func skipStoredArticles(cfg *config.Config, pipe *pipeline.Pipeline, storages []storage.Storage,
+	articles []*models.Article, dateRange utils.DateRange, stats *models.CrawlerStats) []*models.Article {
+
+	if !cfg.Crawler.SkipExisting || len(articles) == 0 {
+		return articles
+	}
+
+	pipe.Identify(articles)
+	ids := make([]int64, len(articles))
+	for i, article := range articles {
+		ids[i] = article.ID
+	}
+	known := knownArticles(storages, ids, dateRange)
+
+	var remaining []*models.Article
+	for _, article := range articles {
+		if !known[article.ID] {
+			remaining = append(remaining, article)
+		}
+	}
+	if skipped := len(articles) - len(remaining); skipped > 0 {
+		fmt.Printf("    - 跳过 %d 篇已存储文章\n", skipped)
+		stats.Skipped += skipped
+	}
+	return remaining
+}
+
+// saveArticles 规范化、校验文章并下载图片，保存到各个存储，label用于输出当前位置
+func saveArticles(pipe *pipeline.Pipeline, assets *crawler.AssetDownloader, storages []storage.Storage,
+	articles []*models.Article, dateRange utils.DateRange, stats *models.CrawlerStats, label string) {
+
+	if len(articles) == 0 {
+		return
+	}
+
+	// 规范化并校验文章，未通过的写入隔离区
+	articles, rejected, err := pipe.Process(articles, dateRange)
+	if err != nil {
+		log.Printf("写入隔离区失败: %v", err)
+	}
+	for _, item := range rejected {
+		fmt.Printf("    ✗ 隔离文章 [%s]: %s\n", item.Article.Title, strings.Join(item.Reasons, "; "))
+	}
+	stats.Quarantined += len(rejected)
+
+	// 下载图片，本地路径随文章一起保存
+	if assets != nil {
+		for _, article := range articles {
+			if n := assets.Download(article); n > 0 {
+				fmt.Printf("    ✓ 下载 %d 张图片 [%s]\n", n, article.Title)
+			}
+		}
+	}
+
+	// 保存到各个存储
+	for _, store := range storages {
+		if err := store.SaveBatch(articles); err != nil {
+			log.Printf("保存到%s失败: %v", store.GetStorageType(), err)
+		} else {
+			fmt.Printf("    ✓ 保存 %d 篇文章到%s (%s)\n", len(articles), store.GetStorageType(), label)
+		}
+	}
+
+	// 更新统计
+	stats.TotalArticles += len(articles)
+}
+
 // createStorages 创建存储实例
 func createStorages(cfg *config.Config) ([]storage.Storage, error) {
 	var storages []storage.Storage
@@ -273,6 +858,9 @@ func createStorages(cfg *config.Config) ([]storage.Storage, error) {
 				cfg.Storage.CSV.OutputDir,
 				cfg.Storage.CSV.FilePrefix,
 			)
+			if cfg.Dedupe.SuppressOnSave {
+				csvStorage.EnableDedupe(cfg.Dedupe.MaxDistance)
+			}
 			storages = append(storages, csvStorage)
 
 		case "mysql":
@@ -284,8 +872,14 @@ func createStorages(cfg *config.Config) ([]storage.Storage, error) {
 				cfg.Storage.MySQL.Database,
 				cfg.Storage.MySQL.Charset,
 			)
+			if cfg.Dedupe.SuppressOnSave {
+				mysqlStorage.EnableDedupe(cfg.Dedupe.MaxDistance)
+			}
 			storages = append(storages, mysqlStorage)
 
+		case "search":
+			storages = append(storages, search.NewIndexStorage(cfg.Search.IndexPath))
+
 		default:
 			return nil, fmt.Errorf("不支持的存储类型: %s", storageType)
 		}
@@ -298,6 +892,49 @@ func createStorages(cfg *config.Config) ([]storage.Storage, error) {
 	return storages, nil
 }
 
+// recordRun 将本次运行写入本地运行日志以及支持运行记录的存储
+func recordRun(cfg *config.Config, storages []storage.Storage, dateRanges []utils.DateRange,
+	stats *models.CrawlerStats, status string) *models.CrawlRun {
+
+	endTime := time.Now()
+	if stats.Duration == 0 {
+		stats.Duration = endTime.Sub(stats.StartTime)
+	}
+
+	run := &models.CrawlRun{
+		ID:         newRunID(stats.StartTime),
+		ConfigHash: cfg.Fingerprint(),
+		Status:     status,
+		StartTime:  stats.StartTime,
+		EndTime:    endTime,
+		Stats:      *stats,
+	}
+	if len(dateRanges) > 0 {
+		run.RangeStart = dateRanges[0].Start.Format("2006-01-02")
+		run.RangeEnd = dateRanges[len(dateRanges)-1].End.Format("2006-01-02")
+	}
+
+	runStores := []storage.RunStore{storage.NewRunLog(cfg.Storage.RunLog)}
+	for _, store := range storages {
+		if runStore, ok := store.(storage.RunStore); ok {
+			runStores = append(runStores, runStore)
+		}
+	}
+
+	for _, runStore := range runStores {
+		if err := runStore.SaveRun(run); err != nil {
+			log.Printf("记录运行失败: %v", err)
+		}
+	}
+	fmt.Printf("运行ID: %s\n", run.ID)
+	return run
+}
+
+// newRunID 生成运行ID，格式为 开始时间-随机后缀
+func newRunID(startTime time.Time) string {
+	return fmt.Sprintf("%s-%04x", startTime.Format("20060102-150405"), rand.Intn(0x10000))
+}
+
 // closeStorages 关闭所有存储
 func closeStorages(storages []storage.Storage) {
 	for _, store := range storages {
@@ -314,23 +951,32 @@ func showFinalStats(stats *models.CrawlerStats) {
 	fmt.Printf("完成任务: %d\n", stats.CompletedTasks)
 	fmt.Printf("失败任务: %d\n", stats.FailedTasks)
 	fmt.Printf("总文章数: %d\n", stats.TotalArticles)
+	fmt.Printf("隔离文章: %d\n", stats.Quarantined)
+	fmt.Printf("跳过文章: %d\n", stats.Skipped)
 	fmt.Printf("耗时: %v\n", stats.Duration.Round(time.Second))
 	fmt.Printf("平均速度: %.2f 篇/秒\n", stats.ArticlesPerSec)
 }
 
+// 请求间隔的随机延迟范围 [randomDelayMin, randomDelayMax)，按毫秒取值
+const (
+	randomDelayMin = -2000 * time.Millisecond
+	randomDelayMax = 3000 * time.Millisecond
+)
+
 // sleepWithRandomDelay 睡眠指定时间并添加随机延迟
-// 随机延迟范围为 [-2ms, +2ms]
+// 随机延迟范围为 [-2s, +3s)
 func sleepWithRandomDelay(baseDuration time.Duration) {
-	// 生成 -2 到 +2 毫秒的随机延迟
-	randomMs := rand.Intn(5000) - 2000 // 生成 -2, -1, 0, 1, 2
-	randomDelay := time.Duration(randomMs) * time.Millisecond
+	time.Sleep(randomDelay(baseDuration))
+}
 
-	totalSleep := baseDuration + randomDelay
+// randomDelay 在基础间隔上加随机延迟，结果不小于0
+func randomDelay(baseDuration time.Duration) time.Duration {
+	randomMs := rand.Intn(int((randomDelayMax-randomDelayMin)/time.Millisecond)) + int(randomDelayMin/time.Millisecond)
+	totalSleep := baseDuration + time.Duration(randomMs)*time.Millisecond
 
 	// 确保睡眠时间不会是负数
 	if totalSleep < 0 {
 		totalSleep = 0
 	}
-
-	time.Sleep(totalSleep)
+	return totalSleep
 }
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/scheduler"
+	"github.com/Lan-ce-lot/data-people/utils"
+	"github.com/spf13/cobra"
+)
+
+// daemonCmd represents the daemon command
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "按cron表达式定时抓取",
+	Long: `持续运行，按配置文件 schedule.jobs 中的cron表达式定时抓取最近若干天
+
+每个任务抓取截至当天的最近 lookback_days 天，refetch 为true时重新抓取已存储的文章以检测修订。
+任务依次运行，不会重叠；抓取期间持有 schedule.lock_file，与手动运行的 crawl 互斥。
+各任务最近一次运行的状态写入 schedule.state_file，重启后立即补上停机期间错过的运行。
+修改配置文件后自动重新加载任务，无需重启。
+
+示例：
+  data-people daemon
+  data-people daemon --config config.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runDaemon()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+}
+
+// scheduledJob 解析后的定时任务及其下一次运行时间
+type scheduledJob struct {
+	config.ScheduleJob
+	schedule *scheduler.Schedule
+	next     time.Time
+}
+
+func runDaemon() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+	jobs, err := parseScheduleJobs(cfg.Schedule.Jobs)
+	if err != nil {
+		log.Fatalf("解析定时任务失败: %v", err)
+	}
+	state, err := scheduler.LoadState(cfg.Schedule.StateFile)
+	if err != nil {
+		log.Fatalf("加载守护进程状态失败: %v", err)
+	}
+	planScheduleJobs(jobs, state, time.Now())
+	showSchedule(jobs)
+
+	// 配置文件变化时只保留最新的一份，在两次运行之间应用
+	reloads := make(chan *config.Config, 1)
+	config.WatchConfig(func(newCfg *config.Config) {
+		select {
+		case <-reloads:
+		default:
+		}
+		reloads <- newCfg
+	})
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+
+	for {
+		var (
+			timer *time.Timer
+			wake  <-chan time.Time
+		)
+		job := nextScheduledJob(jobs)
+		if job != nil {
+			timer = time.NewTimer(time.Until(job.next))
+			wake = timer.C
+		}
+
+		select {
+		case <-wake:
+			if !runScheduledJob(cfg, job, state, signalChan) {
+				fmt.Println("守护进程退出")
+				return
+			}
+			job.next = job.schedule.Next(time.Now())
+			if !job.next.IsZero() {
+				fmt.Printf("任务 %s 下次运行: %s\n", job.Name, job.next.Format("2006-01-02 15:04"))
+			}
+
+		case newCfg := <-reloads:
+			newJobs, err := parseScheduleJobs(newCfg.Schedule.Jobs)
+			if err != nil {
+				log.Printf("新配置中的定时任务无效，继续使用原有任务: %v", err)
+				break
+			}
+			if newCfg.Schedule.StateFile != cfg.Schedule.StateFile {
+				newState, err := scheduler.LoadState(newCfg.Schedule.StateFile)
+				if err != nil {
+					log.Printf("加载新的状态文件失败，继续使用原有配置: %v", err)
+					break
+				}
+				state = newState
+			}
+			cfg, jobs = newCfg, newJobs
+			planScheduleJobs(jobs, state, time.Now())
+			fmt.Println("✓ 已重新加载定时任务")
+			showSchedule(jobs)
+
+		case <-signalChan:
+			fmt.Println("收到中断信号，守护进程退出")
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// parseScheduleJobs 解析各任务的cron表达式并检查配置
+func parseScheduleJobs(jobs []config.ScheduleJob) ([]*scheduledJob, error) {
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("没有配置定时任务 (schedule.jobs)")
+	}
+
+	names := make(map[string]bool)
+	var parsed []*scheduledJob
+	for _, job := range jobs {
+		if job.Name == "" {
+			return nil, fmt.Errorf("定时任务缺少名称: %q", job.Cron)
+		}
+		if names[job.Name] {
+			return nil, fmt.Errorf("定时任务名称重复: %s", job.Name)
+		}
+		names[job.Name] = true
+		if job.LookbackDays <= 0 {
+			return nil, fmt.Errorf("任务 %s 的 lookback_days 必须大于0", job.Name)
+		}
+
+		schedule, err := scheduler.ParseCron(job.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("任务 %s: %v", job.Name, err)
+		}
+		parsed = append(parsed, &scheduledJob{ScheduleJob: job, schedule: schedule})
+	}
+	return parsed, nil
+}
+
+// planScheduleJobs 计算各任务的下一次运行时间；上次运行之后本应触发而错过的任务立即运行
+func planScheduleJobs(jobs []*scheduledJob, state *scheduler.State, now time.Time) {
+	for _, job := range jobs {
+		job.next = job.schedule.Next(now)
+		last := state.Job(job.Name)
+		if last == nil || last.LastStart.IsZero() {
+			continue
+		}
+		if missed := job.schedule.Next(last.LastStart); !missed.IsZero() && missed.Before(now) {
+			job.next = now
+		}
+	}
+}
+
+// nextScheduledJob 下一个要运行的任务，没有会触发的任务时返回nil
+func nextScheduledJob(jobs []*scheduledJob) *scheduledJob {
+	var next *scheduledJob
+	for _, job := range jobs {
+		if job.next.IsZero() {
+			continue
+		}
+		if next == nil || job.next.Before(next.next) {
+			next = job
+		}
+	}
+	return next
+}
+
+// runScheduledJob 抓取任务对应的日期范围并记录状态，抓取被中断时返回false
+func runScheduledJob(cfg *config.Config, job *scheduledJob, state *scheduler.State, interrupt <-chan os.Signal) bool {
+	jobCfg := *cfg
+	end := time.Now()
+	start := end.AddDate(0, 0, -(job.LookbackDays - 1))
+	jobCfg.DateRange.StartDate = start.Format("2006-01-02")
+	jobCfg.DateRange.EndDate = end.Format("2006-01-02")
+	if job.Refetch {
+		jobCfg.Crawler.SkipExisting = false
+	}
+
+	fmt.Printf("\n[%s] 运行定时任务 %s: %s 到 %s\n", time.Now().Format("2006-01-02 15:04:05"),
+		job.Name, jobCfg.DateRange.StartDate, jobCfg.DateRange.EndDate)
+
+	jobState := &scheduler.JobState{LastStart: time.Now()}
+	urlBuilder := utils.NewURLBuilder(jobCfg.Crawler.BaseSearchURL, jobCfg.Crawler.BaseLayoutURL)
+	dateRanges, err := urlBuilder.ParseSpecificDateRange(jobCfg.DateRange.StartDate, jobCfg.DateRange.EndDate)
+
+	var run *models.CrawlRun
+	if err == nil {
+		run, err = executeCrawl(&jobCfg, dateRanges, interrupt)
+	}
+	jobState.LastEnd = time.Now()
+	if err != nil {
+		log.Printf("定时任务 %s 失败: %v", job.Name, err)
+		jobState.LastStatus = scheduler.JobStatusFailed
+		jobState.LastError = err.Error()
+	} else {
+		jobState.LastStatus = run.Status
+		jobState.LastRunID = run.ID
+	}
+
+	if err := state.Record(job.Name, jobState); err != nil {
+		log.Printf("保存守护进程状态失败: %v", err)
+	}
+	return jobState.LastStatus != models.RunStatusInterrupted
+}
+
+// showSchedule 显示各任务的cron表达式和下一次运行时间
+func showSchedule(jobs []*scheduledJob) {
+	fmt.Println("定时任务:")
+	for _, job := range jobs {
+		next := "不会触发"
+		if !job.next.IsZero() {
+			next = job.next.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("  %-16s %-16s 最近%d天  下次运行: %s\n", job.Name, job.schedule, job.LookbackDays, next)
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/dedupe"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dedupeSource      string
+	dedupeMaxDistance int
+	dedupeMark        bool
+	dedupeFormat      string
+	dedupeFrom        string
+	dedupeTo          string
+)
+
+// dedupeCmd represents the dedupe command
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "检测近似重复的文章",
+	Long: `检测近似重复的文章
+
+按正文的精确哈希和SimHash指纹将近似重复的文章聚成簇，
+每簇选发布日期最早的文章作为规范副本。使用--mark将重复标记写回存储。
+
+示例：
+  data-people dedupe
+  data-people dedupe --max-distance 5 --format json
+  data-people dedupe --source mysql --mark`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runDedupe()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dedupeCmd)
+
+	dedupeCmd.Flags().StringVar(&dedupeSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+	dedupeCmd.Flags().IntVar(&dedupeMaxDistance, "max-distance", -1, "SimHash海明距离阈值 (默认使用配置)")
+	dedupeCmd.Flags().BoolVar(&dedupeMark, "mark", false, "将重复标记写回存储")
+	dedupeCmd.Flags().StringVar(&dedupeFormat, "format", "table", "输出格式 (table, json)")
+	dedupeCmd.Flags().StringVar(&dedupeFrom, "from", "", "发布日期下限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	dedupeCmd.Flags().StringVar(&dedupeTo, "to", "", "发布日期上限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+}
+
+func runDedupe() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	maxDistance := cfg.Dedupe.MaxDistance
+	if dedupeMaxDistance >= 0 {
+		maxDistance = dedupeMaxDistance
+	}
+
+	var filter storage.ArticleFilter
+	if filter.From, err = parseDateBound(dedupeFrom, false); err != nil {
+		log.Fatalf("解析--from失败: %v", err)
+	}
+	if filter.To, err = parseDateBound(dedupeTo, true); err != nil {
+		log.Fatalf("解析--to失败: %v", err)
+	}
+	if dedupeMark && (!filter.From.IsZero() || !filter.To.IsZero()) {
+		log.Fatalf("--mark 会替换全部重复标记，不能与--from/--to同时使用")
+	}
+
+	reader, closeReader, err := openReader(cfg, dedupeSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	// 收集指纹，旧数据没有指纹时现场计算
+	var items []dedupe.Item
+	err = reader.ForEach(filter, func(article *models.Article) error {
+		if article.ContentHash == "" {
+			dedupe.Fingerprint(article)
+		}
+		items = append(items, dedupe.Item{
+			ID:          article.ID,
+			URL:         article.URL,
+			Title:       article.Title,
+			PublishDate: article.PublishDate,
+			ContentHash: article.ContentHash,
+			SimHash:     article.SimHash,
+		})
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("读取文章失败: %v", err)
+	}
+
+	clusters := dedupe.ClusterItems(items, maxDistance)
+
+	switch dedupeFormat {
+	case "table":
+		printDedupeClusters(clusters, len(items), maxDistance)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(clusters); err != nil {
+			log.Fatalf("输出重复簇失败: %v", err)
+		}
+	default:
+		log.Fatalf("不支持的输出格式: %s", dedupeFormat)
+	}
+
+	if !dedupeMark {
+		return
+	}
+
+	marker, ok := reader.(storage.DuplicateMarker)
+	if !ok {
+		log.Fatalf("数据来源不支持写入重复标记")
+	}
+	marks := make(map[int64]int64)
+	for _, cluster := range clusters {
+		for _, member := range cluster.Members {
+			marks[member.Item.ID] = cluster.Canonical.ID
+		}
+	}
+	if err := marker.MarkDuplicates(marks); err != nil {
+		log.Fatalf("写入重复标记失败: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ 已标记 %d 篇重复文章\n", len(marks))
+}
+
+// printDedupeClusters 以表格形式输出重复簇
+func printDedupeClusters(clusters []dedupe.Cluster, total, maxDistance int) {
+	duplicates := 0
+	for _, cluster := range clusters {
+		duplicates += len(cluster.Members)
+	}
+
+	fmt.Println("=== 近似重复检测 ===")
+	fmt.Printf("文章总数: %d\n", total)
+	fmt.Printf("海明距离阈值: %d\n", maxDistance)
+	fmt.Printf("重复簇: %d\n", len(clusters))
+	fmt.Printf("重复文章: %d\n", duplicates)
+
+	for i, cluster := range clusters {
+		fmt.Printf("\n[%d] %s %s\n", i+1, cluster.Canonical.PublishDate.Format("2006-01-02"), cluster.Canonical.Title)
+		fmt.Printf("    规范副本: %s\n", cluster.Canonical.URL)
+		for _, member := range cluster.Members {
+			fmt.Printf("    重复(距离%d): %s %s\n", member.Distance,
+				member.Item.PublishDate.Format("2006-01-02"), member.Item.URL)
+		}
+	}
+}
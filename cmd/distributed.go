@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/distributed"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/utils"
+	"github.com/spf13/cobra"
+)
+
+// coordinatorProgressInterval coordinator输出进度的间隔
+const coordinatorProgressInterval = time.Minute
+
+// workerShutdownTimeout worker收到中断信号后等待当前请求结束的最长时间
+const workerShutdownTimeout = 2 * time.Minute
+
+var (
+	coordinatorAddr  string
+	coordinatorReset bool
+	workerURL        string
+	workerID         string
+)
+
+// coordinatorCmd represents the coordinator command
+var coordinatorCmd = &cobra.Command{
+	Use:   "coordinator",
+	Short: "启动分布式抓取的coordinator",
+	Long: `启动分布式抓取的coordinator，持有抓取任务计划并通过HTTP向worker发放有时限的租约
+
+任务按与 crawl 相同的方式生成（补抓计划、具体日期范围或年份范围，每个时间段一个任务）。
+worker需要在租约到期前续约，到期未续约的任务重新分配；分配次数达到 distributed.max_attempts 的任务标记为失败。
+任务状态写入 distributed.state_file，重启后继续未完成的任务，使用 --reset 重新生成任务。
+
+接口列表：
+  POST /api/lease       领取任务
+  POST /api/heartbeat   续约
+  POST /api/complete    汇报结果
+  GET  /api/status      任务进度，参数 tasks=1 时包含全部任务
+
+示例：
+  data-people coordinator --start-date 1949-01-01 --end-date 2025-12-31
+  data-people coordinator --plan gaps_plan.json --addr 0.0.0.0:9090
+  data-people coordinator --reset`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runCoordinator()
+	},
+}
+
+// workerCmd represents the worker command
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "启动分布式抓取的worker",
+	Long: `启动分布式抓取的worker，从coordinator领取任务并抓取，结果写入本机配置的存储
+
+抓取期间定期续约，全部任务结束后退出。多个worker可以运行在同一台机器上，
+此时应使用各自的CSV输出目录或共用MySQL存储；worker不使用 schedule.lock_file。
+
+示例：
+  data-people worker --coordinator http://127.0.0.1:9090
+  data-people worker --config worker2.yaml --id worker-2`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runWorker()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(coordinatorCmd)
+	rootCmd.AddCommand(workerCmd)
+
+	coordinatorCmd.Flags().StringVar(&startDate, "start-date", "", "开始日期 (YYYY-MM-DD)")
+	coordinatorCmd.Flags().StringVar(&endDate, "end-date", "", "结束日期 (YYYY-MM-DD)")
+	coordinatorCmd.Flags().StringVar(&planFile, "plan", "", "补抓计划文件 (由 gaps 命令生成)，指定后忽略日期范围设置")
+	coordinatorCmd.Flags().StringVar(&coordinatorAddr, "addr", "", "监听地址 (默认使用配置文件设置)")
+	coordinatorCmd.Flags().BoolVar(&coordinatorReset, "reset", false, "忽略已有的任务状态，重新生成任务")
+
+	workerCmd.Flags().StringVar(&workerURL, "coordinator", "", "coordinator地址 (默认使用配置文件设置)")
+	workerCmd.Flags().StringVar(&workerID, "id", "", "worker标识 (默认为 主机名-进程ID)")
+}
+
+func runCoordinator() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+	if startDate != "" {
+		cfg.DateRange.StartDate = startDate
+	}
+	if endDate != "" {
+		cfg.DateRange.EndDate = endDate
+	}
+	if coordinatorAddr != "" {
+		cfg.Distributed.Addr = coordinatorAddr
+	}
+
+	coordinator := distributed.NewCoordinator(cfg.Distributed)
+	resumed := false
+	if !coordinatorReset {
+		if resumed, err = coordinator.Load(); err != nil {
+			log.Fatalf("恢复任务状态失败: %v", err)
+		}
+	}
+	if resumed {
+		fmt.Printf("从状态文件恢复任务: %s\n", cfg.Distributed.StateFile)
+	} else {
+		dateRanges, err := crawlDateRanges(cfg, planFile)
+		if err != nil {
+			log.Fatalf("生成抓取任务失败: %v", err)
+		}
+		if err := coordinator.Plan(dateRanges); err != nil {
+			log.Fatalf("保存任务状态失败: %v", err)
+		}
+	}
+	showCoordinatorProgress(coordinator.Status(false))
+
+	server := &http.Server{
+		Addr:              cfg.Distributed.Addr,
+		Handler:           coordinator.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		ticker := time.NewTicker(coordinatorProgressInterval)
+		defer ticker.Stop()
+		finished := false
+		for {
+			select {
+			case <-ticker.C:
+				status := coordinator.Status(false)
+				if finished && status.Finished() {
+					continue
+				}
+				finished = status.Finished()
+				showCoordinatorProgress(status)
+			case <-signalChan:
+				fmt.Println("\n收到中断信号，正在关闭coordinator...")
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				server.Shutdown(ctx)
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("✓ coordinator已启动: http://%s\n", cfg.Distributed.Addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("coordinator异常退出: %v", err)
+	}
+}
+
+// showCoordinatorProgress 显示任务进度
+func showCoordinatorProgress(status *distributed.Status) {
+	fmt.Printf("[%s] 任务: 共 %d，待分配 %d，进行中 %d，完成 %d，失败 %d；文章 %d 篇\n",
+		time.Now().Format("2006-01-02 15:04:05"), status.Total, status.Pending, status.Running,
+		status.Completed, status.Failed, status.Stats.TotalArticles)
+	if status.Finished() {
+		fmt.Println("✓ 全部任务已结束")
+	}
+}
+
+func runWorker() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+	if workerURL != "" {
+		cfg.Distributed.CoordinatorURL = workerURL
+	}
+	if workerID == "" {
+		hostname, _ := os.Hostname()
+		workerID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	showCrawlHeader(cfg)
+	fmt.Printf("coordinator: %s\n", cfg.Distributed.CoordinatorURL)
+	fmt.Printf("worker标识: %s\n", workerID)
+	fmt.Println()
+
+	env, err := newCrawlEnv(cfg)
+	if err != nil {
+		log.Fatalf("初始化抓取失败: %v", err)
+	}
+	defer env.Close()
+
+	var (
+		mu         sync.Mutex
+		dateRanges []utils.DateRange
+		stats      = &models.CrawlerStats{StartTime: time.Now(), ReportedTotals: make(map[string]int)}
+	)
+	crawl := func(task *models.Task) (*models.CrawlerStats, error) {
+		dateRange := utils.DateRange{Start: task.StartDate, End: task.EndDate}
+		taskStats := &models.CrawlerStats{ReportedTotals: make(map[string]int)}
+		err := env.crawlRange(dateRange, taskStats)
+
+		mu.Lock()
+		defer mu.Unlock()
+		dateRanges = append(dateRanges, dateRange)
+		stats.TotalTasks++
+		if err == errCrawlStopped {
+			log.Printf("抓取已停止，时间段未完成 [%s]", dateRange.String())
+		} else if err != nil {
+			log.Printf("处理时间段失败 [%s]: %v", dateRange.String(), err)
+			stats.FailedTasks++
+		} else {
+			log.Printf("✓ 处理时间段成功 [%s]\n", dateRange.String())
+			stats.CompletedTasks++
+		}
+		stats.Merge(taskStats)
+		return taskStats, err
+	}
+
+	worker := distributed.NewWorker(workerID, distributed.NewClient(cfg.Distributed.CoordinatorURL), cfg.Distributed.PollInterval)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		worker.Run(crawl, stop)
+		close(done)
+	}()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+
+	// 被中断时正在抓取的任务不再汇报，租约到期后由其他worker重新抓取
+	// 等待当前请求结束后才关闭存储，超时则直接退出，不关闭仍在写入的存储
+	status := models.RunStatusCompleted
+	select {
+	case <-done:
+	case <-signalChan:
+		fmt.Println("\n收到中断信号，正在优雅关闭...")
+		close(stop)
+		env.Stop()
+		status = models.RunStatusInterrupted
+
+		fmt.Println("等待当前请求结束...")
+		select {
+		case <-done:
+		case <-time.After(workerShutdownTimeout):
+			log.Printf("等待当前请求结束超时 (%v)，直接退出", workerShutdownTimeout)
+			os.Exit(1)
+		case <-signalChan:
+			log.Printf("再次收到中断信号，直接退出")
+			os.Exit(1)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	stats.Duration = time.Since(stats.StartTime)
+	if stats.Duration > 0 {
+		stats.ArticlesPerSec = float64(stats.TotalArticles) / stats.Duration.Seconds()
+	}
+	showFinalStats(stats)
+
+	sort.Slice(dateRanges, func(i, j int) bool {
+		return dateRanges[i].Start.Before(dateRanges[j].Start)
+	})
+	recordRun(cfg, env.storages, dateRanges, stats, status)
+}
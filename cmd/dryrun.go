@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/crawler"
+	"github.com/Lan-ce-lot/data-people/utils"
+)
+
+// searchPageSize 检索结果每页的position数
+const searchPageSize = 20
+
+// windowPlan 一个时间段的请求计划
+type windowPlan struct {
+	articles int    // 预计文章数，-1表示未知
+	source   string // 文章数来源
+	requests int    // 请求数
+	sleeps   int    // 请求间隔次数
+	urls     []string
+}
+
+// runDryRun 显示各时间段会请求的URL，估算请求数和耗时，不发出抓取请求
+// probe为true时每个时间段发出一次检索请求，读取网站报告的文章总数
+func runDryRun(cfg *config.Config, dateRanges []utils.DateRange, probe bool, articlesPerDay int, allURLs bool) {
+	showCrawlHeader(cfg)
+	fmt.Println()
+
+	urlBuilder := utils.NewURLBuilder(cfg.Crawler.BaseSearchURL, cfg.Crawler.BaseLayoutURL)
+	var httpClient *crawler.HTTPClient
+	var parser *crawler.Parser
+	if probe {
+		if cfg.Crawler.Mode == crawlModeLayout {
+			fmt.Println("layout方式不支持探测文章数，忽略 --probe")
+			probe = false
+		} else {
+			httpClient = crawler.NewHTTPClient(cfg.Crawler.Timeout, cfg.Crawler.UserAgent, cfg.Crawler.BaseCookies)
+			parser = crawler.NewParser(httpClient)
+		}
+	}
+
+	var (
+		probeTime    time.Duration
+		probes       int
+		unknown      int
+		noTotal      int
+		requests     int
+		sleeps       int
+		articles     int
+		knownWindows int
+	)
+	for i, dateRange := range dateRanges {
+		var plan *windowPlan
+		var err error
+		if cfg.Crawler.Mode == crawlModeLayout {
+			plan = planLayoutWindow(urlBuilder, dateRange, articlesPerDay)
+		} else {
+			total := -1
+			source := "未知"
+			if probe {
+				if i > 0 {
+					sleepWithRandomDelay(cfg.Crawler.RequestInterval)
+				}
+				start := time.Now()
+				total, err = probeWindowTotal(cfg, httpClient, parser, urlBuilder, dateRange)
+				probeTime += time.Since(start)
+				probes++
+				if err != nil {
+					log.Printf("探测文章数失败 [%s]: %v", dateRange.String(), err)
+					source = "探测失败"
+				} else if total >= 0 {
+					source = "探测"
+				} else {
+					source = "探测未返回总数"
+					noTotal++
+				}
+			}
+			if total < 0 && articlesPerDay > 0 {
+				total = articlesPerDay * len(dateRange.Days())
+				source = "按每日文章数"
+			}
+			if plan, err = planSearchWindow(urlBuilder, dateRange, total); err != nil {
+				log.Fatalf("构建搜索URL失败: %v", err)
+			}
+			plan.source = source
+		}
+
+		fmt.Printf("[%d/%d] %s  ", i+1, len(dateRanges), dateRange.String())
+		if plan.articles < 0 {
+			fmt.Printf("文章数未知 (%s)  请求至少 %d 次\n", plan.source, plan.requests)
+			unknown++
+		} else {
+			fmt.Printf("文章 %d 篇 (%s)  请求 %d 次\n", plan.articles, plan.source, plan.requests)
+			articles += plan.articles
+			knownWindows++
+		}
+		urls := plan.urls
+		if !allURLs && len(urls) > 1 {
+			urls = urls[:1]
+		}
+		for _, url := range urls {
+			fmt.Printf("    %s\n", url)
+		}
+		if len(urls) < len(plan.urls) {
+			fmt.Printf("    ... 共 %d 个URL，使用 --urls 显示全部\n", len(plan.urls))
+		}
+
+		requests += plan.requests
+		sleeps += plan.sleeps
+	}
+
+	minDelay, meanDelay, maxDelay := requestDelay(cfg.Crawler.RequestInterval)
+	var latency time.Duration
+	if probes > 0 {
+		latency = probeTime / time.Duration(probes)
+	}
+	retryWait := retryBackoff(cfg.Crawler.RequestInterval, cfg.Crawler.MaxRetries)
+
+	fmt.Println("\n=== 抓取估算 ===")
+	fmt.Printf("时间段: %d\n", len(dateRanges))
+	if knownWindows > 0 {
+		fmt.Printf("文章数: %d (%d 个时间段)\n", articles, knownWindows)
+	}
+	fmt.Printf("请求数: %d\n", requests)
+	if unknown > 0 {
+		fmt.Printf("  其中 %d 个时间段文章数未知，只计入确定会发出的请求；使用 --probe 探测或 --articles-per-day 指定每日文章数\n", unknown)
+	}
+	if noTotal > 0 {
+		fmt.Printf("  %d 个时间段探测时页面上没有检索结果数，探测未返回总数；可以用 --articles-per-day 估算\n", noTotal)
+	}
+	if cfg.Crawler.Mode == crawlModeLayout {
+		fmt.Println("  layout方式按每天一个版面请求计，第二版及以后的版面请求未计入")
+	}
+	if cfg.Crawler.SkipExisting && (cfg.Crawler.Mode == crawlModeLayout || cfg.Crawler.StopAfterKnownPages > 0) {
+		fmt.Println("  未扣除已存储的文章，实际请求数可能更少（search方式文章都已存储的时间段只请求一次）")
+	}
+	fmt.Printf("请求间隔: %d 次，每次 %v + 随机延迟 [%v, %v)，平均 %v\n",
+		sleeps, cfg.Crawler.RequestInterval, randomDelayMin, randomDelayMax, meanDelay.Round(time.Millisecond))
+
+	expected := time.Duration(sleeps)*meanDelay + time.Duration(requests)*latency
+	shortest := time.Duration(sleeps) * minDelay
+	longest := time.Duration(sleeps)*maxDelay +
+		time.Duration(requests)*(time.Duration(cfg.Crawler.MaxRetries+1)*cfg.Crawler.Timeout+retryWait)
+
+	if probes > 0 {
+		fmt.Printf("预计耗时: %v (请求耗时按探测平均 %v 计)\n", expected.Round(time.Second), latency.Round(time.Millisecond))
+	} else {
+		fmt.Printf("预计耗时: %v (不含请求本身的耗时，使用 --probe 测量)\n", expected.Round(time.Second))
+	}
+	fmt.Printf("最短耗时: %v (随机延迟均取最小值)\n", shortest.Round(time.Second))
+	fmt.Printf("最长耗时: %v (随机延迟均取最大值，每次请求都超时并重试 %d 次)\n", longest.Round(time.Second), cfg.Crawler.MaxRetries)
+}
+
+// planSearchWindow 按search方式的翻页规则计算请求：每个position请求对应一篇文章，
+// 每页20个position，遇到空结果结束当前页，下一页第一个position为空时结束时间段
+// total为-1时只计入第一次请求
+func planSearchWindow(urlBuilder *utils.URLBuilder, dateRange utils.DateRange, total int) (*windowPlan, error) {
+	plan := &windowPlan{articles: total}
+
+	var positions []int // 每页请求的position数
+	if total < 0 {
+		positions = []int{1}
+		plan.sleeps = 1
+	} else {
+		for remaining := total; remaining > 0; remaining -= searchPageSize {
+			if remaining >= searchPageSize {
+				positions = append(positions, searchPageSize)
+			} else {
+				positions = append(positions, remaining+1) // 最后一个position无结果
+			}
+		}
+		positions = append(positions, 1) // 下一页第一个position无结果
+		pages := (total + searchPageSize - 1) / searchPageSize
+		// 每篇文章后一次、每页之后一次、时间段结束后一次
+		plan.sleeps = total + pages + 1
+	}
+
+	for i, count := range positions {
+		for position := 0; position < count; position++ {
+			url, err := urlBuilder.BuildSearchURL(dateRange.Start, dateRange.End, i+1, position)
+			if err != nil {
+				return nil, err
+			}
+			plan.urls = append(plan.urls, url)
+		}
+	}
+	plan.requests = len(plan.urls)
+	return plan, nil
+}
+
+// planLayoutWindow 按layout方式计算请求：每天一个版面请求，加上每篇文章一个请求
+// 第二版及以后的版面数量无法预知，不计入
+func planLayoutWindow(urlBuilder *utils.URLBuilder, dateRange utils.DateRange, articlesPerDay int) *windowPlan {
+	days := dateRange.Days()
+	plan := &windowPlan{articles: -1}
+	for _, day := range days {
+		plan.urls = append(plan.urls, urlBuilder.BuildLayoutURL(day, 1))
+	}
+	plan.requests = len(days)
+	// 每天之间一次、时间段结束后一次
+	plan.sleeps = len(days)
+	if articlesPerDay > 0 {
+		plan.articles = articlesPerDay * len(days)
+		plan.source = "按每日文章数"
+		plan.requests += plan.articles
+		plan.sleeps += plan.articles
+	}
+	return plan
+}
+
+// probeWindowTotal 请求时间段检索结果的第一个position，返回网站报告的文章总数
+// 页面上没有检索结果数时无法得知整个时间段的总数，返回-1
+func probeWindowTotal(cfg *config.Config, httpClient *crawler.HTTPClient, parser *crawler.Parser,
+	urlBuilder *utils.URLBuilder, dateRange utils.DateRange) (int, error) {
+
+	searchURL, err := urlBuilder.BuildSearchURL(dateRange.Start, dateRange.End, 1, 0)
+	if err != nil {
+		return -1, err
+	}
+	body, err := httpClient.GetWithRetryAndPageInfo(searchURL, cfg.Crawler.MaxRetries, cfg.Crawler.RequestInterval, 1, searchPageSize)
+	if err != nil {
+		return -1, err
+	}
+	response, err := parser.ParseSearchResponse(body, searchURL)
+	if err != nil {
+		return -1, err
+	}
+	if len(response.Data.Results) == 0 {
+		return 0, nil
+	}
+	if response.Data.Total > 0 {
+		return response.Data.Total, nil
+	}
+	return -1, nil
+}
+
+// requestDelay 计算 sleepWithRandomDelay 的最短、平均和最长睡眠时间
+// 随机延迟按毫秒均匀取值，负数睡眠按0计
+func requestDelay(base time.Duration) (min, mean, max time.Duration) {
+	clamp := func(d time.Duration) time.Duration {
+		if d < 0 {
+			return 0
+		}
+		return d
+	}
+
+	var sum time.Duration
+	count := 0
+	for delay := randomDelayMin; delay < randomDelayMax; delay += time.Millisecond {
+		sum += clamp(base + delay)
+		count++
+	}
+	return clamp(base + randomDelayMin), sum / time.Duration(count), clamp(base + randomDelayMax - time.Millisecond)
+}
+
+// retryBackoff 一次请求重试maxRetries次时的等待时间总和（不含限流时的加长等待）
+func retryBackoff(interval time.Duration, maxRetries int) time.Duration {
+	var total time.Duration
+	for i := 1; i <= maxRetries; i++ {
+		total += interval * time.Duration(1<<uint(i-1))
+	}
+	return total
+}
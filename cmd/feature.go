@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/crawler"
+	"github.com/spf13/cobra"
+)
+
+// featureCmd represents the feature command
+var featureCmd = &cobra.Command{
+	Use:   "feature [raw]",
+	Short: "调试特征内容解析",
+	Long: `调试特征内容解析
+
+解析文章页面中的特征内容（如"【人民日报2025年8月30日 第1版 要闻】"），
+输出报名、日期、版次、版面类型和解析可信度。支持中文数字、无【】括号和字段缺失的写法。
+
+示例：
+  data-people feature "人民日报一九四九年十月一日 第一版"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		printFeatureLine(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(featureCmd)
+}
+
+func printFeatureLine(raw string) {
+	feature := crawler.ParseFeatureLine(raw)
+
+	fmt.Printf("报名:   %s\n", feature.Newspaper)
+	fmt.Printf("日期:   %s\n", formatFeatureDate(feature.Date))
+	fmt.Printf("版次:   %s\n", feature.EditionName())
+	fmt.Printf("类型:   %s\n", feature.Type)
+	fmt.Printf("可信度: %.2f\n", feature.Confidence)
+}
+
+// formatFeatureDate 日期格式化为YYYY-MM-DD，零值为空
+func formatFeatureDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
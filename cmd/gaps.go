@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/coverage"
+	"github.com/Lan-ce-lot/data-people/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gapsSource    string
+	gapsRunSource string
+	gapsFrom      string
+	gapsTo        string
+	gapsMinRatio  float64
+	gapsPlan      string
+	gapsFormat    string
+)
+
+// gapsCmd represents the gaps command
+var gapsCmd = &cobra.Command{
+	Use:   "gaps",
+	Short: "检查已存储数据的缺口并生成补抓计划",
+	Long: `检查已存储数据的缺口并生成补抓计划
+
+按发布日期和版次遍历已存储的文章，检查：
+- 没有任何文章的日期
+- 版次不连续的日期（如只有第1、2、4版）
+- 文章数明显少于网站报告总数的时间段（报告总数来自历史运行记录）
+
+生成的补抓计划可直接交给 crawl --plan 执行。
+
+示例：
+  data-people gaps --from 1978 --to 1992
+  data-people gaps --plan gaps_plan.json
+  data-people crawl --plan gaps_plan.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runGaps()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gapsCmd)
+
+	gapsCmd.Flags().StringVar(&gapsSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+	gapsCmd.Flags().StringVar(&gapsRunSource, "run-source", "auto", "运行记录来源 (auto, local, mysql)，用于读取网站报告总数")
+	gapsCmd.Flags().StringVar(&gapsFrom, "from", "", "检查范围开始 (YYYY、YYYY-MM或YYYY-MM-DD，默认最早文章日期)")
+	gapsCmd.Flags().StringVar(&gapsTo, "to", "", "检查范围结束 (YYYY、YYYY-MM或YYYY-MM-DD，默认最晚文章日期)")
+	gapsCmd.Flags().Float64Var(&gapsMinRatio, "min-ratio", 0.9, "已存储数量低于网站报告总数的该比例时视为缺口")
+	gapsCmd.Flags().StringVar(&gapsPlan, "plan", "", "补抓计划输出文件")
+	gapsCmd.Flags().StringVar(&gapsFormat, "format", "table", "输出格式 (table, json)")
+}
+
+func runGaps() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	opts := coverage.Options{MinRatio: gapsMinRatio}
+	if opts.From, err = parseDateBound(gapsFrom, false); err != nil {
+		log.Fatalf("解析--from失败: %v", err)
+	}
+	if opts.To, err = parseDateBound(gapsTo, true); err != nil {
+		log.Fatalf("解析--to失败: %v", err)
+	}
+
+	opts.ReportedTotals, err = loadReportedTotals(cfg)
+	if err != nil {
+		log.Printf("读取网站报告总数失败，跳过数量检查: %v", err)
+	}
+
+	reader, closeReader, err := openReader(cfg, gapsSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	report, err := coverage.Analyze(reader, opts)
+	if err != nil {
+		log.Fatalf("检查数据缺口失败: %v", err)
+	}
+
+	switch gapsFormat {
+	case "table":
+		printGapReport(report)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("输出检查结果失败: %v", err)
+		}
+	default:
+		log.Fatalf("不支持的输出格式: %s", gapsFormat)
+	}
+
+	if gapsPlan != "" {
+		plan := report.Plan()
+		if err := utils.SavePlan(gapsPlan, plan); err != nil {
+			log.Fatalf("写入补抓计划失败: %v", err)
+		}
+		// 写到标准错误，不影响 --format json 的输出
+		fmt.Fprintf(os.Stderr, "✓ 补抓计划已写入 %s (%d 个时间段)，执行: data-people crawl --plan %s\n",
+			gapsPlan, len(plan.Windows), gapsPlan)
+	}
+}
+
+// loadReportedTotals 汇总历史运行记录中网站报告的时间段总数，较新的运行优先
+func loadReportedTotals(cfg *config.Config) (map[string]int, error) {
+	runStore, closeFn, err := openRunStore(cfg, gapsRunSource)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+
+	runs, err := runStore.ListRuns(0)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int)
+	for _, run := range runs {
+		for key, total := range run.Stats.ReportedTotals {
+			if _, exists := totals[key]; !exists {
+				totals[key] = total
+			}
+		}
+	}
+	return totals, nil
+}
+
+// printGapReport 以表格形式输出检查结果
+func printGapReport(report *coverage.Report) {
+	fmt.Printf("检查范围: %s 到 %s\n", report.From.Format("2006-01-02"), report.To.Format("2006-01-02"))
+	fmt.Printf("文章总数: %d\n", report.TotalArticles)
+	fmt.Printf("发现缺口: %d 处\n", len(report.Gaps))
+	if len(report.Gaps) == 0 {
+		return
+	}
+
+	fmt.Println()
+	for _, gap := range report.Gaps {
+		period := gap.Start.Format("2006-01-02")
+		if !gap.End.Equal(gap.Start) {
+			period += "~" + gap.End.Format("2006-01-02")
+		}
+		fmt.Printf("%-13s  %-21s  %s\n", gap.Kind, period, gap.Detail)
+	}
+}
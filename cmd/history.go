@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/Lan-ce-lot/data-people/utils"
+	"github.com/spf13/cobra"
+)
+
+var historySource string
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history <url|id>",
+	Short: "查看文章的历史版本",
+	Long: `查看文章的历史版本
+
+重新抓取时文章标题或正文发生变化，旧版本会被归档。
+该命令列出文章的所有版本，并按句子显示相邻版本之间的差异。
+文章可以用URL或规范ID指定，历史版本按规范ID查找。
+
+示例：
+  data-people history http://paper.people.com.cn/rmrb/html/2025-08/30/nw.D110000renmrb_20250830_1-01.htm
+  data-people history 30090001
+  data-people history <url> --source mysql`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		showHistory(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historySource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+}
+
+func showHistory(target string) {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	reader, closeReader, err := openReader(cfg, historySource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	revisionStore, ok := reader.(storage.RevisionStore)
+	if !ok {
+		log.Fatalf("数据来源不支持历史版本")
+	}
+
+	filter := storage.ArticleFilter{URL: target, Limit: 1}
+	if id, err := strconv.ParseInt(target, 10, 64); err == nil {
+		filter = storage.ArticleFilter{ID: id, Limit: 1}
+	}
+
+	var current *models.Article
+	err = reader.ForEach(filter, func(article *models.Article) error {
+		current = article
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("读取文章失败: %v", err)
+	}
+
+	// 当前版本不存在时，按URL中的站点文档ID查找历史版本
+	id := filter.ID
+	if current != nil {
+		id = current.ID
+	} else if id == 0 {
+		id = models.DocumentID(target)
+	}
+
+	var revisions []*models.Revision
+	if id != 0 {
+		if revisions, err = revisionStore.GetRevisions(id); err != nil {
+			log.Fatalf("读取历史版本失败: %v", err)
+		}
+	}
+	if current == nil && len(revisions) == 0 {
+		log.Fatalf("文章不存在: %s", target)
+	}
+
+	// 按时间顺序排列所有版本，当前版本在最后
+	var versions []*models.Article
+	var labels []string
+	for _, revision := range revisions {
+		article := revision.Article
+		versions = append(versions, &article)
+		labels = append(labels, fmt.Sprintf("归档于 %s", revision.ArchivedAt.Format("2006-01-02 15:04:05")))
+	}
+	if current != nil {
+		versions = append(versions, current)
+		labels = append(labels, "当前版本")
+	}
+
+	fmt.Printf("=== %s ===\n", target)
+	fmt.Printf("版本数: %d\n", len(versions))
+	for i, version := range versions {
+		fmt.Printf("  v%d  %s  %s\n", i+1, labels[i], version.Title)
+	}
+
+	for i := 1; i < len(versions); i++ {
+		fmt.Printf("\n--- v%d -> v%d ---\n", i, i+1)
+		printArticleDiff(versions[i-1], versions[i])
+	}
+}
+
+// printArticleDiff 输出两个版本之间的差异，只显示变化的句子
+func printArticleDiff(oldArticle, newArticle *models.Article) {
+	if oldArticle.Title != newArticle.Title {
+		fmt.Printf("标题: %s -> %s\n", oldArticle.Title, newArticle.Title)
+	}
+	if oldArticle.Subtitle != newArticle.Subtitle {
+		fmt.Printf("副标题: %s -> %s\n", oldArticle.Subtitle, newArticle.Subtitle)
+	}
+
+	unchanged := 0
+	changed := false
+	for _, line := range utils.DiffText(oldArticle.Content, newArticle.Content) {
+		switch line.Op {
+		case utils.DiffEqual:
+			unchanged++
+		case utils.DiffDelete:
+			fmt.Printf("- %s\n", line.Text)
+			changed = true
+		case utils.DiffInsert:
+			fmt.Printf("+ %s\n", line.Text)
+			changed = true
+		}
+	}
+
+	if !changed {
+		fmt.Println("正文无变化（仅空白或标点不同）")
+	}
+	fmt.Printf("(%d 句未变化)\n", unchanged)
+}
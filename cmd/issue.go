@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	issueSource string
+	issueFormat string
+)
+
+// issueCmd represents the issue command
+var issueCmd = &cobra.Command{
+	Use:   "issue",
+	Short: "按版面查看整期报纸",
+	Long: `按版面查看整期报纸
+
+按电子版抓取（crawler.mode: layout）时会保存每天的版面和文章在版面中的顺序，
+该命令按版次和顺序列出当天的全部文章，并标出尚未保存的文章。
+
+示例：
+  data-people issue show 2025-08-30
+  data-people issue show 2025-08-30 --source mysql --format json`,
+}
+
+// issueShowCmd represents the issue show command
+var issueShowCmd = &cobra.Command{
+	Use:   "show <YYYY-MM-DD>",
+	Short: "显示指定日期的版面和文章",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		showIssue(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(issueCmd)
+	issueCmd.AddCommand(issueShowCmd)
+
+	issueCmd.PersistentFlags().StringVar(&issueSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+	issueShowCmd.Flags().StringVar(&issueFormat, "format", "table", "输出格式 (table, json)")
+}
+
+func showIssue(value string) {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		log.Fatalf("日期格式错误: %s (应为 YYYY-MM-DD)", value)
+	}
+
+	reader, closeReader, err := openReader(cfg, issueSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	issueStore, ok := reader.(storage.IssueStore)
+	if !ok {
+		log.Fatalf("数据来源不支持版面记录")
+	}
+	issue, err := issueStore.GetIssue(date)
+	if err != nil {
+		log.Fatalf("读取版面失败: %v", err)
+	}
+
+	// 当天已保存的文章，用于补充标题和标出缺失的文章
+	saved := make(map[int64]*models.Article)
+	err = reader.ForEach(storage.ArticleFilter{From: date, To: date}, func(article *models.Article) error {
+		saved[article.ID] = article
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("读取文章失败: %v", err)
+	}
+
+	switch issueFormat {
+	case "table":
+		printIssue(issue, saved)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(issue); err != nil {
+			log.Fatalf("输出JSON失败: %v", err)
+		}
+	default:
+		log.Fatalf("不支持的输出格式: %s", issueFormat)
+	}
+}
+
+// printIssue 按版次和顺序输出版面中的文章
+func printIssue(issue *models.Issue, saved map[int64]*models.Article) {
+	total, missing := 0, 0
+
+	fmt.Printf("人民日报 %s  共%d版\n", issue.Date.Format("2006-01-02"), issue.TotalEditions)
+	for _, page := range issue.Pages {
+		fmt.Printf("\n%s %s\n", page.EditionName(), page.Name)
+		if page.PDFURL != "" {
+			fmt.Printf("  PDF: %s\n", page.PDFURL)
+		}
+		for _, item := range page.Articles {
+			total++
+			title := item.Title
+			mark := ""
+			if article, ok := saved[models.DocumentID(item.URL)]; ok {
+				if article.Title != "" {
+					title = article.Title
+				}
+			} else {
+				missing++
+				mark = "  (未保存)"
+			}
+			fmt.Printf("  %2d. %s%s\n      %s\n", item.Ordinal, title, mark, item.URL)
+		}
+	}
+
+	fmt.Printf("\n共 %d 篇文章，未保存 %d 篇\n", total, missing)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/nlp"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keywordsSource string
+	keywordsMinDF  int
+	keywordsURL    string
+	keywordsMethod string
+	keywordsTopK   int
+)
+
+// keywordsCmd represents the keywords command
+var keywordsCmd = &cobra.Command{
+	Use:   "keywords",
+	Short: "关键词提取与IDF统计",
+	Long: `关键词提取与IDF统计
+
+抓取时会按配置为每篇文章提取关键词。TF-IDF使用的逆文档频率从已存储的文章中统计，
+首次使用或语料明显增加后需要运行 build-idf 重新生成。
+
+示例：
+  data-people keywords build-idf
+  data-people keywords build-idf --source mysql --min-df 3
+  data-people keywords extract "国务院常务会议研究推动新能源汽车产业高质量发展"
+  data-people keywords extract --url <url> --method textrank`,
+}
+
+// keywordsBuildIDFCmd represents the keywords build-idf command
+var keywordsBuildIDFCmd = &cobra.Command{
+	Use:   "build-idf",
+	Short: "从已存储的文章统计IDF",
+	Run: func(cmd *cobra.Command, args []string) {
+		buildIDF()
+	},
+}
+
+// keywordsExtractCmd represents the keywords extract command
+var keywordsExtractCmd = &cobra.Command{
+	Use:   "extract [text]",
+	Short: "对文本或已存储的文章提取关键词",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		extractKeywords(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keywordsCmd)
+	keywordsCmd.AddCommand(keywordsBuildIDFCmd)
+	keywordsCmd.AddCommand(keywordsExtractCmd)
+
+	keywordsCmd.PersistentFlags().StringVar(&keywordsSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+	keywordsBuildIDFCmd.Flags().IntVar(&keywordsMinDF, "min-df", 2, "只保留至少出现在这么多篇文章中的词")
+	keywordsExtractCmd.Flags().StringVar(&keywordsURL, "url", "", "从存储中读取指定URL的文章")
+	keywordsExtractCmd.Flags().StringVar(&keywordsMethod, "method", "", "提取方法 tfidf 或 textrank (默认使用配置)")
+	keywordsExtractCmd.Flags().IntVar(&keywordsTopK, "top-k", 0, "关键词数量 (默认使用配置)")
+}
+
+func buildIDF() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	segmenter, err := nlp.NewSegmenter(cfg.Keywords.UserDict)
+	if err != nil {
+		log.Fatalf("加载词典失败: %v", err)
+	}
+	extractor := nlp.NewExtractor(segmenter, nil)
+
+	reader, closeReader, err := openReader(cfg, keywordsSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	idf := nlp.NewIDF()
+	err = reader.ForEach(storage.ArticleFilter{}, func(article *models.Article) error {
+		idf.AddDocument(extractor.Candidates(article.Title + "\n" + article.Content))
+		if idf.Docs%10000 == 0 {
+			fmt.Printf("已统计 %d 篇文章\n", idf.Docs)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("读取文章失败: %v", err)
+	}
+
+	terms := len(idf.DF)
+	idf.Prune(keywordsMinDF)
+	if err := idf.Save(cfg.Keywords.IDFPath); err != nil {
+		log.Fatalf("保存IDF失败: %v", err)
+	}
+
+	fmt.Printf("✓ IDF统计完成: %d 篇文章, %d 个词 (保留 %d 个)\n", idf.Docs, terms, len(idf.DF))
+	fmt.Printf("  保存到: %s\n", cfg.Keywords.IDFPath)
+}
+
+func extractKeywords(args []string) {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	method := cfg.Keywords.Method
+	if keywordsMethod != "" {
+		method = keywordsMethod
+	}
+	topK := cfg.Keywords.TopK
+	if keywordsTopK > 0 {
+		topK = keywordsTopK
+	}
+
+	var text string
+	switch {
+	case len(args) == 1:
+		text = args[0]
+	case keywordsURL != "":
+		text = readArticleText(cfg, keywordsURL)
+	default:
+		log.Fatalf("请提供文本或 --url")
+	}
+
+	segmenter, err := nlp.NewSegmenter(cfg.Keywords.UserDict)
+	if err != nil {
+		log.Fatalf("加载词典失败: %v", err)
+	}
+	idf, err := nlp.LoadIDF(cfg.Keywords.IDFPath)
+	if err != nil {
+		log.Fatalf("加载IDF失败: %v", err)
+	}
+	extractor := nlp.NewExtractor(segmenter, idf)
+
+	keywords, err := extractor.Extract(text, method, topK)
+	if err != nil {
+		log.Fatalf("提取关键词失败: %v", err)
+	}
+
+	fmt.Printf("分词: %s\n", strings.Join(segmenter.Cut(text), " / "))
+	fmt.Printf("关键词 (%s):\n", method)
+	for _, keyword := range keywords {
+		fmt.Printf("  %-12s %.4f\n", keyword.Word, keyword.Weight)
+	}
+}
+
+// readArticleText 从存储中读取文章的正文
+func readArticleText(cfg *config.Config, url string) string {
+	reader, closeReader, err := openReader(cfg, keywordsSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	var article *models.Article
+	err = reader.ForEach(storage.ArticleFilter{URL: url, Limit: 1}, func(a *models.Article) error {
+		article = a
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("读取文章失败: %v", err)
+	}
+	if article == nil {
+		log.Fatalf("文章不存在: %s", url)
+	}
+	return article.Content
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var mergeOutput string
+
+// mergeCmd represents the merge command
+var mergeCmd = &cobra.Command{
+	Use:   "merge <分片目录>...",
+	Short: "合并各分片的CSV/JSONL输出",
+	Long: `合并 crawl --shard 在各台机器上生成的输出目录
+
+文章按规范ID去重：已存在且内容相同的文章跳过；内容不同时保留抓取时间较晚的版本，旧版本写入历史版本文件。
+重复标记合并到输出目录；各目录（含子目录）中的 .jsonl 文件（版面、历史版本、隔离区、运行日志等）
+按相对路径合并到输出目录的同名文件，相同的行只保留一份。
+可以重复运行，已合并的内容不会重复写入。
+
+示例：
+  data-people merge --output ./data shard1/data shard2/data shard3/data
+  data-people merge shard2/data shard3/data   # 合并到配置文件中的CSV输出目录`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runMerge(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "", "输出目录 (默认使用配置文件中的CSV输出目录)")
+}
+
+func runMerge(dirs []string) {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+	if mergeOutput == "" {
+		mergeOutput = cfg.Storage.CSV.OutputDir
+	}
+
+	output := storage.NewCSVStorage(mergeOutput, cfg.Storage.CSV.FilePrefix)
+	if err := output.Init(); err != nil {
+		log.Fatalf("初始化输出目录失败: %v", err)
+	}
+	defer output.Close()
+
+	outputAbs, _ := filepath.Abs(mergeOutput)
+	fmt.Printf("输出目录: %s\n", mergeOutput)
+
+	total := &storage.MergeResult{}
+	lines := 0
+	for _, dir := range dirs {
+		if abs, _ := filepath.Abs(dir); abs == outputAbs {
+			fmt.Printf("跳过输出目录本身: %s\n", dir)
+			continue
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			log.Fatalf("分片目录不存在: %s", dir)
+		}
+
+		result, err := output.MergeFrom(dir)
+		if err != nil {
+			log.Fatalf("合并文章失败 [%s]: %v", dir, err)
+		}
+		added, err := mergeJSONLFiles(mergeOutput, dir)
+		if err != nil {
+			log.Fatalf("合并JSONL文件失败 [%s]: %v", dir, err)
+		}
+		fmt.Printf("✓ %s: 文章 %d 篇，新增 %d，修订 %d，已存在 %d，较旧版本 %d；JSONL新增 %d 行\n",
+			dir, result.Articles, result.Added, result.Revised, result.Unchanged, result.Older, added)
+
+		total.Articles += result.Articles
+		total.Added += result.Added
+		total.Revised += result.Revised
+		total.Unchanged += result.Unchanged
+		total.Older += result.Older
+		lines += added
+	}
+
+	fmt.Println("\n=== 合并统计 ===")
+	fmt.Printf("读取文章: %d\n", total.Articles)
+	fmt.Printf("新增文章: %d\n", total.Added)
+	fmt.Printf("修订文章: %d\n", total.Revised)
+	fmt.Printf("已存在文章: %d\n", total.Unchanged)
+	fmt.Printf("较旧版本: %d\n", total.Older)
+	fmt.Printf("JSONL新增行数: %d\n", lines)
+}
+
+// mergeJSONLFiles 将分片目录中的 .jsonl 文件按相对路径合并到输出目录，返回追加的行数
+// 输出目录位于分片目录之内时跳过输出目录
+func mergeJSONLFiles(outputDir, dir string) (int, error) {
+	outputAbs, _ := filepath.Abs(outputDir)
+	total := 0
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if abs, _ := filepath.Abs(path); abs == outputAbs {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".jsonl") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		added, err := storage.MergeJSONLines(filepath.Join(outputDir, rel), path)
+		if err != nil {
+			return err
+		}
+		total += added
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/storage"
+)
+
+// openReader 打开用于读取已存储文章的存储
+// source为空时使用配置中第一个存储类型
+func openReader(cfg *config.Config, source string) (storage.Reader, func(), error) {
+	if source == "" {
+		if len(cfg.Storage.Types) == 0 {
+			return nil, nil, fmt.Errorf("没有配置任何存储类型")
+		}
+		source = cfg.Storage.Types[0]
+	}
+
+	switch source {
+	case "csv":
+		csvStorage := storage.NewCSVStorage(
+			cfg.Storage.CSV.OutputDir,
+			cfg.Storage.CSV.FilePrefix,
+		)
+		return csvStorage, func() { csvStorage.Close() }, nil
+
+	case "mysql":
+		mysqlStorage := storage.NewMySQLStorage(
+			cfg.Storage.MySQL.Host,
+			cfg.Storage.MySQL.Port,
+			cfg.Storage.MySQL.Username,
+			cfg.Storage.MySQL.Password,
+			cfg.Storage.MySQL.Database,
+			cfg.Storage.MySQL.Charset,
+		)
+		if err := mysqlStorage.Init(); err != nil {
+			return nil, nil, fmt.Errorf("初始化mysql存储失败: %v", err)
+		}
+		return mysqlStorage, func() { mysqlStorage.Close() }, nil
+
+	default:
+		return nil, nil, fmt.Errorf("不支持的读取来源: %s", source)
+	}
+}
+
+// parseDateBound 解析命令行中的日期边界，支持 YYYY、YYYY-MM、YYYY-MM-DD
+// end为true时返回该年/月的最后一天，用作包含当天的上限
+func parseDateBound(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01", value); err == nil {
+		if end {
+			return t.AddDate(0, 1, -1), nil
+		}
+		return t, nil
+	}
+	if t, err := time.Parse("2006", value); err == nil {
+		if end {
+			return t.AddDate(1, 0, -1), nil
+		}
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("日期格式错误: %s (支持 YYYY、YYYY-MM、YYYY-MM-DD)", value)
+}
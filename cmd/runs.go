@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runsSource string
+	runsLimit  int
+)
+
+// runsCmd represents the runs command
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "查看历史抓取运行记录",
+	Long: `查看历史抓取运行记录
+
+运行记录保存在MySQL的crawl_stats表和本地运行日志中。
+默认在配置了MySQL时读取MySQL，否则读取本地运行日志。
+
+示例：
+  data-people runs list
+  data-people runs list --limit 5 --source local
+  data-people runs show 20250830-103015-1a2b`,
+}
+
+// runsListCmd represents the runs list command
+var runsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出最近的运行记录",
+	Run: func(cmd *cobra.Command, args []string) {
+		listRuns()
+	},
+}
+
+// runsShowCmd represents the runs show command
+var runsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "显示指定运行的详细信息",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		showRun(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runsCmd)
+	runsCmd.AddCommand(runsListCmd)
+	runsCmd.AddCommand(runsShowCmd)
+
+	runsCmd.PersistentFlags().StringVar(&runsSource, "source", "auto", "记录来源 (auto, local, mysql)")
+	runsListCmd.Flags().IntVar(&runsLimit, "limit", 20, "显示条数 (0表示全部)")
+}
+
+func listRuns() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	runStore, closeFn, err := openRunStore(cfg, runsSource)
+	if err != nil {
+		log.Fatalf("打开运行记录失败: %v", err)
+	}
+	defer closeFn()
+
+	runs, err := runStore.ListRuns(runsLimit)
+	if err != nil {
+		log.Fatalf("读取运行记录失败: %v", err)
+	}
+	if len(runs) == 0 {
+		fmt.Println("暂无运行记录")
+		return
+	}
+
+	fmt.Printf("%-22s  %-12s  %-19s  %-23s  %6s  %6s  %8s  %10s\n",
+		"运行ID", "状态", "开始时间", "日期范围", "完成", "失败", "文章数", "耗时")
+	for _, run := range runs {
+		fmt.Printf("%-22s  %-12s  %-19s  %-23s  %6d  %6d  %8d  %10v\n",
+			run.ID,
+			run.Status,
+			run.StartTime.Format("2006-01-02 15:04:05"),
+			run.RangeStart+"~"+run.RangeEnd,
+			run.Stats.CompletedTasks,
+			run.Stats.FailedTasks,
+			run.Stats.TotalArticles,
+			run.Stats.Duration.Round(time.Second),
+		)
+	}
+}
+
+func showRun(id string) {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	runStore, closeFn, err := openRunStore(cfg, runsSource)
+	if err != nil {
+		log.Fatalf("打开运行记录失败: %v", err)
+	}
+	defer closeFn()
+
+	run, err := runStore.GetRun(id)
+	if err != nil {
+		log.Fatalf("读取运行记录失败: %v", err)
+	}
+
+	fmt.Printf("运行ID: %s\n", run.ID)
+	fmt.Printf("状态: %s\n", run.Status)
+	fmt.Printf("配置指纹: %s\n", run.ConfigHash)
+	fmt.Printf("日期范围: %s 到 %s\n", run.RangeStart, run.RangeEnd)
+	fmt.Printf("开始时间: %s\n", run.StartTime.Format("2006-01-02 15:04:05"))
+	fmt.Printf("结束时间: %s\n", run.EndTime.Format("2006-01-02 15:04:05"))
+	showFinalStats(&run.Stats)
+}
+
+// openRunStore 选择运行记录来源，auto表示配置了MySQL时使用MySQL，否则使用本地运行日志
+func openRunStore(cfg *config.Config, source string) (storage.RunStore, func(), error) {
+	if source == "auto" || source == "" {
+		source = "local"
+		for _, storageType := range cfg.Storage.Types {
+			if storageType == "mysql" {
+				source = "mysql"
+			}
+		}
+	}
+
+	switch source {
+	case "local":
+		return storage.NewRunLog(cfg.Storage.RunLog), func() {}, nil
+	case "mysql":
+		mysqlStorage := storage.NewMySQLStorage(
+			cfg.Storage.MySQL.Host,
+			cfg.Storage.MySQL.Port,
+			cfg.Storage.MySQL.Username,
+			cfg.Storage.MySQL.Password,
+			cfg.Storage.MySQL.Database,
+			cfg.Storage.MySQL.Charset,
+		)
+		if err := mysqlStorage.Init(); err != nil {
+			return nil, nil, fmt.Errorf("初始化mysql存储失败: %v", err)
+		}
+		return mysqlStorage, func() { mysqlStorage.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("不支持的记录来源: %s", source)
+	}
+}
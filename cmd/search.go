@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/search"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchFrom   string
+	searchTo     string
+	searchLimit  int
+	searchSource string
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search <关键词>",
+	Short: "在本地全文索引中检索文章",
+	Long: `在本地全文索引中检索文章标题和正文
+
+多个关键词用空格分隔，结果需同时包含全部关键词，按相关度排序。
+在storage.types中加入"search"即可在抓取时增量更新索引，
+也可以通过 search rebuild 从已有存储重建索引。
+
+示例：
+  data-people search "改革开放" --from 1978 --to 1992
+  data-people search "经济 特区" --limit 50
+  data-people search rebuild --source csv`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSearch(strings.Join(args, " "))
+	},
+}
+
+// searchRebuildCmd represents the search rebuild command
+var searchRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "从已有存储重建全文索引",
+	Run: func(cmd *cobra.Command, args []string) {
+		rebuildSearchIndex()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.AddCommand(searchRebuildCmd)
+
+	searchCmd.Flags().StringVar(&searchFrom, "from", "", "发布日期下限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	searchCmd.Flags().StringVar(&searchTo, "to", "", "发布日期上限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "显示条数")
+	searchRebuildCmd.Flags().StringVar(&searchSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+}
+
+func runSearch(query string) {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	from, err := parseDateBound(searchFrom, false)
+	if err != nil {
+		log.Fatalf("解析--from失败: %v", err)
+	}
+	to, err := parseDateBound(searchTo, true)
+	if err != nil {
+		log.Fatalf("解析--to失败: %v", err)
+	}
+
+	index, err := search.Open(cfg.Search.IndexPath)
+	if err != nil {
+		log.Fatalf("打开索引失败: %v", err)
+	}
+	if index.Len() == 0 {
+		fmt.Println("索引为空，请先运行 data-people search rebuild")
+		return
+	}
+
+	hits, total := index.Search(query, search.Options{From: from, To: to, Limit: searchLimit})
+	fmt.Printf("共找到 %d 篇文章", total)
+	if total > len(hits) {
+		fmt.Printf("，显示前 %d 篇", len(hits))
+	}
+	fmt.Println()
+
+	for i, hit := range hits {
+		fmt.Printf("\n%d. %s  [%s %s %s]  score=%.2f\n", i+1, hit.Title,
+			hit.PublishDate.Format("2006-01-02"), hit.Edition, hit.Type, hit.Score)
+		fmt.Printf("   %s\n", hit.Snippet)
+		fmt.Printf("   %s\n", hit.URL)
+	}
+}
+
+func rebuildSearchIndex() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	reader, closeReader, err := openReader(cfg, searchSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	index := search.NewIndex(cfg.Search.IndexPath)
+	count := 0
+	err = reader.ForEach(storage.ArticleFilter{}, func(article *models.Article) error {
+		index.Add(article)
+		count++
+		if count%10000 == 0 {
+			fmt.Printf("已索引 %d 篇文章\n", count)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("读取文章失败: %v", err)
+	}
+
+	if err := index.Save(); err != nil {
+		log.Fatalf("写入索引失败: %v", err)
+	}
+	fmt.Printf("✓ 索引重建完成，共 %d 篇文章: %s\n", index.Len(), cfg.Search.IndexPath)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/api"
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr   string
+	serveSource string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "启动本地文章查询API",
+	Long: `启动本地文章查询API，以JSON格式提供已抓取的文章数据
+
+接口列表：
+  GET /api/articles          按日期范围、版次、类型、栏目、标题关键词过滤，游标分页
+                             参数: from, to, edition, type, column, q, limit, cursor
+  GET /api/articles/lookup   按url或规范id查询单篇文章
+  GET /api/aggregates        按year、month、edition、type或column统计文章数量
+                             参数: by, 以及与/api/articles相同的过滤参数
+  GET /api/health            健康检查
+
+示例：
+  data-people serve
+  data-people serve --addr :8080 --source mysql`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runServe()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "", "监听地址 (默认使用配置文件设置)")
+	serveCmd.Flags().StringVar(&serveSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+}
+
+func runServe() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+	if serveAddr != "" {
+		cfg.Server.Addr = serveAddr
+	}
+
+	reader, closeReader, err := openReader(cfg, serveSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           api.NewServer(reader).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signalChan
+		fmt.Println("\n收到中断信号，正在关闭服务...")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+
+	fmt.Printf("✓ 查询服务已启动: http://%s\n", cfg.Server.Addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("查询服务异常退出: %v", err)
+	}
+}
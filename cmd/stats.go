@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSource string
+	statsFormat string
+	statsOutput string
+	statsFrom   string
+	statsTo     string
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "统计已存储的文章",
+	Long: `统计已存储的文章
+
+按年份、月份、版次、类型统计文章数量，并给出平均正文长度和最早/最晚日期。
+支持table、csv、json三种输出格式。
+
+示例：
+  data-people stats
+  data-people stats --source mysql --format json
+  data-people stats --from 2025-01 --to 2025-03 --format csv --output report.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runStats()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+	statsCmd.Flags().StringVar(&statsFormat, "format", "table", "输出格式 (table, csv, json)")
+	statsCmd.Flags().StringVar(&statsOutput, "output", "", "输出文件 (默认输出到终端)")
+	statsCmd.Flags().StringVar(&statsFrom, "from", "", "发布日期下限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	statsCmd.Flags().StringVar(&statsTo, "to", "", "发布日期上限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+}
+
+func runStats() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	var filter storage.ArticleFilter
+	if filter.From, err = parseDateBound(statsFrom, false); err != nil {
+		log.Fatalf("解析--from失败: %v", err)
+	}
+	if filter.To, err = parseDateBound(statsTo, true); err != nil {
+		log.Fatalf("解析--to失败: %v", err)
+	}
+
+	reader, closeReader, err := openReader(cfg, statsSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	var stats *models.CorpusStats
+	if provider, ok := reader.(storage.StatsProvider); ok {
+		stats, err = provider.GetStats(filter)
+	} else {
+		stats, err = storage.CollectStats(reader, filter)
+	}
+	if err != nil {
+		log.Fatalf("统计文章失败: %v", err)
+	}
+
+	out := io.Writer(os.Stdout)
+	if statsOutput != "" {
+		file, err := os.Create(statsOutput)
+		if err != nil {
+			log.Fatalf("创建输出文件失败: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch statsFormat {
+	case "table":
+		writeStatsTable(out, stats)
+	case "csv":
+		err = writeStatsCSV(out, stats)
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(stats)
+	default:
+		log.Fatalf("不支持的输出格式: %s", statsFormat)
+	}
+	if err != nil {
+		log.Fatalf("输出统计结果失败: %v", err)
+	}
+}
+
+// statsSections 分组统计的输出顺序
+func statsSections(stats *models.CorpusStats) []struct {
+	name   string
+	title  string
+	counts []models.StatCount
+} {
+	return []struct {
+		name   string
+		title  string
+		counts []models.StatCount
+	}{
+		{"year", "按年份", stats.ByYear},
+		{"month", "按月份", stats.ByMonth},
+		{"edition", "按版次", stats.ByEdition},
+		{"type", "按类型", stats.ByType},
+	}
+}
+
+// writeStatsTable 以表格形式输出统计结果
+func writeStatsTable(out io.Writer, stats *models.CorpusStats) {
+	fmt.Fprintln(out, "=== 语料统计 ===")
+	fmt.Fprintf(out, "文章总数: %d\n", stats.TotalArticles)
+	fmt.Fprintf(out, "平均正文长度: %.1f 字\n", stats.AvgContentLength)
+	fmt.Fprintf(out, "最早日期: %s\n", stats.EarliestDate)
+	fmt.Fprintf(out, "最晚日期: %s\n", stats.LatestDate)
+
+	for _, section := range statsSections(stats) {
+		fmt.Fprintf(out, "\n--- %s ---\n", section.title)
+		for _, count := range section.counts {
+			key := count.Key
+			if key == "" {
+				key = "(空)"
+			}
+			fmt.Fprintf(out, "%-12s %8d\n", key, count.Count)
+		}
+	}
+}
+
+// writeStatsCSV 以 dimension,key,value 三列的CSV输出统计结果
+func writeStatsCSV(out io.Writer, stats *models.CorpusStats) error {
+	writer := csv.NewWriter(out)
+	records := [][]string{
+		{"dimension", "key", "value"},
+		{"summary", "total_articles", strconv.Itoa(stats.TotalArticles)},
+		{"summary", "avg_content_length", strconv.FormatFloat(stats.AvgContentLength, 'f', 1, 64)},
+		{"summary", "earliest_date", stats.EarliestDate},
+		{"summary", "latest_date", stats.LatestDate},
+	}
+	for _, section := range statsSections(stats) {
+		for _, count := range section.counts {
+			records = append(records, []string{section.name, count.Key, strconv.Itoa(count.Count)})
+		}
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tablesSource string
+	tablesFrom   string
+	tablesTo     string
+	tablesURL    string
+	tablesOut    string
+)
+
+// tablesCmd represents the tables command
+var tablesCmd = &cobra.Command{
+	Use:   "tables",
+	Short: "将文章中的表格导出为CSV文件",
+	Long: `将文章中的表格导出为CSV文件
+
+每个表格导出为一个CSV文件，文件名为 发布日期_文章ID_序号.csv，
+第一行是表头，每行列数相同。导出目录中的 tables_index.csv 列出每个表格的
+发布日期、文章ID、序号、表题和文件名。
+
+示例：
+  data-people tables --from 2025-01 --to 2025-03
+  data-people tables --url http://paper.people.com.cn/rmrb/html/2025-08/30/nw.D110000renmrb_20250830_1-01.htm
+  data-people tables --source mysql --out ./data/tables`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runTables()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tablesCmd)
+
+	tablesCmd.Flags().StringVar(&tablesSource, "source", "", "数据来源 csv 或 mysql (默认使用第一个存储类型)")
+	tablesCmd.Flags().StringVar(&tablesFrom, "from", "", "发布日期下限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	tablesCmd.Flags().StringVar(&tablesTo, "to", "", "发布日期上限 (YYYY、YYYY-MM或YYYY-MM-DD)")
+	tablesCmd.Flags().StringVar(&tablesURL, "url", "", "只导出指定文章的表格")
+	tablesCmd.Flags().StringVar(&tablesOut, "out", "./data/tables", "导出目录")
+}
+
+func runTables() {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	filter := storage.ArticleFilter{URL: tablesURL}
+	if filter.From, err = parseDateBound(tablesFrom, false); err != nil {
+		log.Fatalf("解析--from失败: %v", err)
+	}
+	if filter.To, err = parseDateBound(tablesTo, true); err != nil {
+		log.Fatalf("解析--to失败: %v", err)
+	}
+
+	reader, closeReader, err := openReader(cfg, tablesSource)
+	if err != nil {
+		log.Fatalf("打开数据来源失败: %v", err)
+	}
+	defer closeReader()
+
+	if err := os.MkdirAll(tablesOut, 0755); err != nil {
+		log.Fatalf("创建导出目录失败: %v", err)
+	}
+
+	indexPath := filepath.Join(tablesOut, "tables_index.csv")
+	indexFile, err := os.Create(indexPath)
+	if err != nil {
+		log.Fatalf("创建表格索引失败: %v", err)
+	}
+	defer indexFile.Close()
+	index := csv.NewWriter(indexFile)
+	index.Write([]string{"publish_date", "article_id", "table", "caption", "file"})
+
+	// MySQL中表格存储在单独的表，读取文章时不包含表格
+	tableStore, _ := reader.(storage.TableStore)
+
+	var articles, exported int
+	err = reader.ForEach(filter, func(article *models.Article) error {
+		tables := article.Tables
+		if len(tables) == 0 && tableStore != nil {
+			stored, err := tableStore.GetTables(article.ID)
+			if err != nil {
+				return err
+			}
+			tables = stored
+		}
+		if len(tables) == 0 {
+			return nil
+		}
+
+		articles++
+		for i, table := range tables {
+			name := tableFileName(article, i+1)
+			if err := writeTableCSV(filepath.Join(tablesOut, name), table); err != nil {
+				return err
+			}
+			index.Write([]string{tableDate(article), strconv.FormatInt(article.ID, 10), strconv.Itoa(i + 1), table.Caption, name})
+			exported++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("导出表格失败: %v", err)
+	}
+
+	index.Flush()
+	if err := index.Error(); err != nil {
+		log.Fatalf("写入表格索引失败: %v", err)
+	}
+
+	fmt.Printf("已从 %d 篇文章导出 %d 个表格到 %s\n", articles, exported, tablesOut)
+}
+
+// tableFileName 表格文件名：发布日期_文章ID_序号.csv
+func tableFileName(article *models.Article, index int) string {
+	date := "00000000"
+	if !article.PublishDate.IsZero() {
+		date = article.PublishDate.Format("20060102")
+	}
+	return fmt.Sprintf("%s_%d_%d.csv", date, article.ID, index)
+}
+
+// tableDate 表格索引中的发布日期，没有日期时为空
+func tableDate(article *models.Article) string {
+	if article.PublishDate.IsZero() {
+		return ""
+	}
+	return article.PublishDate.Format("2006-01-02")
+}
+
+// writeTableCSV 写入单个表格，表头和数据行补齐到相同列数，表题写在表格索引中
+func writeTableCSV(path string, table models.ArticleTable) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建表格文件失败: %v", err)
+	}
+	defer file.Close()
+
+	columns := len(table.Header)
+	for _, row := range table.Rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	pad := func(row []string) []string {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		return row
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(pad(table.Header)); err != nil {
+		return fmt.Errorf("写入表头失败: %v", err)
+	}
+	for _, row := range table.Rows {
+		if err := writer.Write(pad(row)); err != nil {
+			return fmt.Errorf("写入表格失败: %v", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("写入表格失败: %v", err)
+	}
+	return file.Close()
+}
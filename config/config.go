@@ -1,6 +1,9 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -13,11 +16,22 @@ import (
 
 // Config 应用配置结构
 type Config struct {
-	App       AppConfig       `mapstructure:"app" yaml:"app"`
-	Crawler   CrawlerConfig   `mapstructure:"crawler" yaml:"crawler"`
-	DateRange DateRangeConfig `mapstructure:"date_range" yaml:"date_range"`
-	Storage   StorageConfig   `mapstructure:"storage" yaml:"storage"`
-	Logging   LoggingConfig   `mapstructure:"logging" yaml:"logging"`
+	App           AppConfig           `mapstructure:"app" yaml:"app"`
+	Crawler       CrawlerConfig       `mapstructure:"crawler" yaml:"crawler"`
+	DateRange     DateRangeConfig     `mapstructure:"date_range" yaml:"date_range"`
+	Storage       StorageConfig       `mapstructure:"storage" yaml:"storage"`
+	Logging       LoggingConfig       `mapstructure:"logging" yaml:"logging"`
+	Server        ServerConfig        `mapstructure:"server" yaml:"server"`
+	Search        SearchConfig        `mapstructure:"search" yaml:"search"`
+	Validation    ValidationConfig    `mapstructure:"validation" yaml:"validation"`
+	Dedupe        DedupeConfig        `mapstructure:"dedupe" yaml:"dedupe"`
+	Normalization NormalizationConfig `mapstructure:"normalization" yaml:"normalization"`
+	Keywords      KeywordsConfig      `mapstructure:"keywords" yaml:"keywords"`
+	Summary       SummaryConfig       `mapstructure:"summary" yaml:"summary"`
+	Columns       ColumnsConfig       `mapstructure:"columns" yaml:"columns"`
+	Assets        AssetsConfig        `mapstructure:"assets" yaml:"assets"`
+	Schedule      ScheduleConfig      `mapstructure:"schedule" yaml:"schedule"`
+	Distributed   DistributedConfig   `mapstructure:"distributed" yaml:"distributed"`
 }
 
 // AppConfig 应用基础配置
@@ -35,6 +49,11 @@ type CrawlerConfig struct {
 	UserAgent       string        `mapstructure:"user_agent" yaml:"user_agent"`
 	BaseCookies     string        `mapstructure:"base_cookies" yaml:"base_cookies"`       // 基础Cookie，不包含页码信息
 	BaseSearchURL   string        `mapstructure:"base_search_url" yaml:"base_search_url"` // 基础搜索URL
+	Mode            string        `mapstructure:"mode" yaml:"mode"`                       // 抓取方式：search 按检索结果分页，layout 按电子版日期和版面
+	BaseLayoutURL   string        `mapstructure:"base_layout_url" yaml:"base_layout_url"` // 电子版版面基础URL，layout方式使用
+
+	SkipExisting        bool `mapstructure:"skip_existing" yaml:"skip_existing"`                   // 跳过所有存储中都已有的文章
+	StopAfterKnownPages int  `mapstructure:"stop_after_known_pages" yaml:"stop_after_known_pages"` // search方式时间段记录中的文章都已存储时，第一次请求后跳过时间段，或连续多少页的文章都已存储后结束时间段，0表示不提前结束
 }
 
 // DateRangeConfig 日期范围配置
@@ -47,9 +66,11 @@ type DateRangeConfig struct {
 
 // StorageConfig 存储配置
 type StorageConfig struct {
-	Types []string    `mapstructure:"types" yaml:"types"`
-	CSV   CSVConfig   `mapstructure:"csv" yaml:"csv"`
-	MySQL MySQLConfig `mapstructure:"mysql" yaml:"mysql"`
+	Types     []string    `mapstructure:"types" yaml:"types"`
+	CSV       CSVConfig   `mapstructure:"csv" yaml:"csv"`
+	MySQL     MySQLConfig `mapstructure:"mysql" yaml:"mysql"`
+	RunLog    string      `mapstructure:"run_log" yaml:"run_log"`       // 本地运行日志文件
+	WindowLog string      `mapstructure:"window_log" yaml:"window_log"` // search方式抓取完的时间段及其文章ID，用于重新抓取时跳过已全部存储的时间段
 }
 
 // CSVConfig CSV存储配置
@@ -79,6 +100,94 @@ type LoggingConfig struct {
 	MaxAge     int    `mapstructure:"max_age" yaml:"max_age"`
 }
 
+// ServerConfig 查询服务配置
+type ServerConfig struct {
+	Addr string `mapstructure:"addr" yaml:"addr"` // 监听地址，如 :8080
+}
+
+// SearchConfig 全文检索配置
+type SearchConfig struct {
+	IndexPath string `mapstructure:"index_path" yaml:"index_path"` // 索引文件路径
+}
+
+// ValidationConfig 数据校验配置
+type ValidationConfig struct {
+	Enabled          bool     `mapstructure:"enabled" yaml:"enabled"`
+	RequiredFields   []string `mapstructure:"required_fields" yaml:"required_fields"`       // 必填字段，如 title, content, publish_date
+	MinContentLength int      `mapstructure:"min_content_length" yaml:"min_content_length"` // 正文最少字符数，0表示不检查
+	CheckDateWindow  bool     `mapstructure:"check_date_window" yaml:"check_date_window"`   // 发布日期必须落在抓取时间段内
+	EditionPattern   string   `mapstructure:"edition_pattern" yaml:"edition_pattern"`       // 版次格式正则，空表示不检查
+	QuarantineDir    string   `mapstructure:"quarantine_dir" yaml:"quarantine_dir"`         // 隔离文件目录
+}
+
+// DedupeConfig 近似重复检测配置
+type DedupeConfig struct {
+	SuppressOnSave bool `mapstructure:"suppress_on_save" yaml:"suppress_on_save"` // 保存时跳过与已有文章重复的文章
+	MaxDistance    int  `mapstructure:"max_distance" yaml:"max_distance"`         // SimHash海明距离阈值
+}
+
+// NormalizationConfig 文本规范化配置，作用于标题、副标题和正文
+type NormalizationConfig struct {
+	Enabled                 bool `mapstructure:"enabled" yaml:"enabled"`
+	NFKC                    bool `mapstructure:"nfkc" yaml:"nfkc"`                                           // Unicode NFKC规范化（保留中文标点）
+	Whitespace              bool `mapstructure:"whitespace" yaml:"whitespace"`                               // 合并空白、去掉空段落
+	TraditionalToSimplified bool `mapstructure:"traditional_to_simplified" yaml:"traditional_to_simplified"` // 繁体转简体
+	Punctuation             bool `mapstructure:"punctuation" yaml:"punctuation"`                             // 紧邻汉字的半角标点转全角
+}
+
+// KeywordsConfig 关键词提取配置
+type KeywordsConfig struct {
+	Enabled  bool   `mapstructure:"enabled" yaml:"enabled"`     // 保存前为文章提取关键词
+	Method   string `mapstructure:"method" yaml:"method"`       // 提取方法 tfidf 或 textrank
+	TopK     int    `mapstructure:"top_k" yaml:"top_k"`         // 每篇文章保留的关键词数
+	IDFPath  string `mapstructure:"idf_path" yaml:"idf_path"`   // 由 keywords build-idf 生成的IDF统计文件
+	UserDict string `mapstructure:"user_dict" yaml:"user_dict"` // 用户词典，每行"词语 词频"，空表示只用内置词典
+}
+
+// SummaryConfig 摘要生成配置
+type SummaryConfig struct {
+	Enabled      bool `mapstructure:"enabled" yaml:"enabled"`             // 保存前为文章生成摘要
+	MaxSentences int  `mapstructure:"max_sentences" yaml:"max_sentences"` // 摘要最多句数
+	MaxLength    int  `mapstructure:"max_length" yaml:"max_length"`       // 摘要最多字数，0表示不限制
+}
+
+// ColumnsConfig 栏目识别配置
+type ColumnsConfig struct {
+	Enabled  bool   `mapstructure:"enabled" yaml:"enabled"`     // 保存前识别文章所属栏目
+	UserDict string `mapstructure:"user_dict" yaml:"user_dict"` // 用户栏目词典，每行"栏目名 [别名...]"，空表示只用内置词典
+}
+
+// AssetsConfig 图片下载配置
+type AssetsConfig struct {
+	Enabled bool   `mapstructure:"enabled" yaml:"enabled"` // 抓取时下载文章图片
+	Dir     string `mapstructure:"dir" yaml:"dir"`         // 图片目录，按内容哈希存储
+}
+
+// ScheduleConfig 守护进程定时抓取配置
+type ScheduleConfig struct {
+	StateFile string        `mapstructure:"state_file" yaml:"state_file"` // 各任务最近一次运行的状态文件
+	LockFile  string        `mapstructure:"lock_file" yaml:"lock_file"`   // 抓取锁文件，防止多个抓取同时运行
+	Jobs      []ScheduleJob `mapstructure:"jobs" yaml:"jobs"`
+}
+
+// ScheduleJob 定时抓取任务
+type ScheduleJob struct {
+	Name         string `mapstructure:"name" yaml:"name"`                   // 任务名称，用于记录运行状态
+	Cron         string `mapstructure:"cron" yaml:"cron"`                   // 5字段cron表达式（分 时 日 月 星期），按本地时区
+	LookbackDays int    `mapstructure:"lookback_days" yaml:"lookback_days"` // 抓取截至当天的最近多少天
+	Refetch      bool   `mapstructure:"refetch" yaml:"refetch"`             // 重新抓取已存储的文章，用于检测修订
+}
+
+// DistributedConfig 分布式抓取配置
+type DistributedConfig struct {
+	Addr           string        `mapstructure:"addr" yaml:"addr"`                       // coordinator监听地址
+	CoordinatorURL string        `mapstructure:"coordinator_url" yaml:"coordinator_url"` // worker连接的coordinator地址
+	LeaseDuration  time.Duration `mapstructure:"lease_duration" yaml:"lease_duration"`   // 租约时长，worker在此期间未发送心跳时任务重新分配
+	MaxAttempts    int           `mapstructure:"max_attempts" yaml:"max_attempts"`       // 每个任务最多分配的次数，超过后标记为失败
+	PollInterval   time.Duration `mapstructure:"poll_interval" yaml:"poll_interval"`     // 暂时没有可分配任务时worker的等待时间
+	StateFile      string        `mapstructure:"state_file" yaml:"state_file"`           // coordinator的任务状态文件，重启后继续未完成的任务
+}
+
 // LoadConfig 使用Viper加载配置文件
 func LoadConfig(configPath string) (*Config, error) {
 	// 设置默认值
@@ -129,6 +238,27 @@ func LoadConfig(configPath string) (*Config, error) {
 	return &config, nil
 }
 
+// Fingerprint 计算影响抓取结果的配置指纹（不包含Cookie和数据库口令）
+func (c *Config) Fingerprint() string {
+	fingerprint := struct {
+		Crawler   CrawlerConfig   `json:"crawler"`
+		DateRange DateRangeConfig `json:"date_range"`
+		Types     []string        `json:"types"`
+	}{
+		Crawler:   c.Crawler,
+		DateRange: c.DateRange,
+		Types:     c.Storage.Types,
+	}
+	fingerprint.Crawler.BaseCookies = ""
+
+	data, err := json.Marshal(fingerprint)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
 // GetConfig 获取当前Viper配置实例（用于动态获取配置值）
 func GetConfig() *viper.Viper {
 	return viper.GetViper()
@@ -159,12 +289,22 @@ func ReloadConfig() error {
 	return viper.ReadInConfig()
 }
 
-// WatchConfig 监控配置文件变化并自动重载
-func WatchConfig() {
-	viper.WatchConfig()
+// WatchConfig 监控配置文件变化并自动重载，onChange不为nil时传入重新解析后的配置
+// 解析失败时只输出错误，不调用onChange
+func WatchConfig(onChange func(*Config)) {
 	viper.OnConfigChange(func(e fsnotify.Event) {
 		fmt.Printf("配置文件发生变化: %s\n", e.Name)
+		if onChange == nil {
+			return
+		}
+		var config Config
+		if err := viper.Unmarshal(&config); err != nil {
+			fmt.Printf("解析配置文件失败: %v\n", err)
+			return
+		}
+		onChange(&config)
 	})
+	viper.WatchConfig()
 }
 
 // CreateDefaultConfig 创建默认配置文件
@@ -192,6 +332,11 @@ func getDefaultConfig() *Config {
 			Timeout:         30 * time.Second,
 			MaxRetries:      3,
 			UserAgent:       "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
+			Mode:            "search",
+			BaseLayoutURL:   "http://paper.people.com.cn/rmrb/pc/layout/",
+
+			SkipExisting:        true,
+			StopAfterKnownPages: 1,
 		},
 		DateRange: DateRangeConfig{
 			StartYear: 1949,
@@ -213,6 +358,8 @@ func getDefaultConfig() *Config {
 				MaxOpenConns: 10,
 				MaxIdleConns: 5,
 			},
+			RunLog:    "./data/crawl_runs.jsonl",
+			WindowLog: "./data/search_windows.jsonl",
 		},
 		Logging: LoggingConfig{
 			Level:      "info",
@@ -221,6 +368,61 @@ func getDefaultConfig() *Config {
 			MaxBackups: 3,
 			MaxAge:     28,
 		},
+		Server: ServerConfig{
+			Addr: "127.0.0.1:8080",
+		},
+		Search: SearchConfig{
+			IndexPath: "./data/search.idx",
+		},
+		Validation: ValidationConfig{
+			Enabled:          true,
+			RequiredFields:   []string{"title", "content", "publish_date"},
+			MinContentLength: 20,
+			CheckDateWindow:  true,
+			EditionPattern:   `^第\d+版$`,
+			QuarantineDir:    "./data/quarantine",
+		},
+		Dedupe: DedupeConfig{
+			SuppressOnSave: false,
+			MaxDistance:    3,
+		},
+		Normalization: NormalizationConfig{
+			Enabled:                 true,
+			NFKC:                    true,
+			Whitespace:              true,
+			TraditionalToSimplified: false,
+			Punctuation:             true,
+		},
+		Keywords: KeywordsConfig{
+			Enabled: true,
+			Method:  "tfidf",
+			TopK:    5,
+			IDFPath: "./data/idf.json",
+		},
+		Summary: SummaryConfig{
+			Enabled:      true,
+			MaxSentences: 3,
+			MaxLength:    200,
+		},
+		Columns: ColumnsConfig{
+			Enabled: true,
+		},
+		Assets: AssetsConfig{
+			Enabled: false,
+			Dir:     "./data/assets",
+		},
+		Schedule: ScheduleConfig{
+			StateFile: "./data/daemon_state.json",
+			LockFile:  "./data/crawl.lock",
+		},
+		Distributed: DistributedConfig{
+			Addr:           "127.0.0.1:9090",
+			CoordinatorURL: "http://127.0.0.1:9090",
+			LeaseDuration:  5 * time.Minute,
+			MaxAttempts:    3,
+			PollInterval:   10 * time.Second,
+			StateFile:      "./data/coordinator_state.json",
+		},
 	}
 }
 
@@ -237,7 +439,11 @@ func setDefaults() {
 	viper.SetDefault("crawler.max_retries", 3)
 	viper.SetDefault("crawler.user_agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
 	viper.SetDefault("crawler.base_cookies", "")
-	viper.SetDefault("crawler.base_search_url", "http://paper.people.com.cn/rmrb/pc/layout/")
+	viper.SetDefault("crawler.base_search_url", "https://data.people.com.cn/rmrb/pd.html")
+	viper.SetDefault("crawler.mode", "search")
+	viper.SetDefault("crawler.base_layout_url", "http://paper.people.com.cn/rmrb/pc/layout/")
+	viper.SetDefault("crawler.skip_existing", true)
+	viper.SetDefault("crawler.stop_after_known_pages", 1)
 
 	// DateRange默认值
 	viper.SetDefault("date_range.start_year", 1949)
@@ -261,6 +467,8 @@ func setDefaults() {
 	viper.SetDefault("storage.mysql.charset", "utf8mb4")
 	viper.SetDefault("storage.mysql.max_open_conns", 10)
 	viper.SetDefault("storage.mysql.max_idle_conns", 5)
+	viper.SetDefault("storage.run_log", "./data/crawl_runs.jsonl")
+	viper.SetDefault("storage.window_log", "./data/search_windows.jsonl")
 
 	// Logging默认值
 	viper.SetDefault("logging.level", "info")
@@ -268,4 +476,61 @@ func setDefaults() {
 	viper.SetDefault("logging.max_size", 100)
 	viper.SetDefault("logging.max_backups", 3)
 	viper.SetDefault("logging.max_age", 28)
+
+	// Server默认值
+	viper.SetDefault("server.addr", "127.0.0.1:8080")
+
+	// Search默认值
+	viper.SetDefault("search.index_path", "./data/search.idx")
+
+	// Validation默认值
+	viper.SetDefault("validation.enabled", true)
+	viper.SetDefault("validation.required_fields", []string{"title", "content", "publish_date"})
+	viper.SetDefault("validation.min_content_length", 20)
+	viper.SetDefault("validation.check_date_window", true)
+	viper.SetDefault("validation.edition_pattern", `^第\d+版$`)
+	viper.SetDefault("validation.quarantine_dir", "./data/quarantine")
+
+	// Dedupe默认值
+	viper.SetDefault("dedupe.suppress_on_save", false)
+	viper.SetDefault("dedupe.max_distance", 3)
+
+	// Normalization默认值
+	viper.SetDefault("normalization.enabled", true)
+	viper.SetDefault("normalization.nfkc", true)
+	viper.SetDefault("normalization.whitespace", true)
+	viper.SetDefault("normalization.traditional_to_simplified", false)
+	viper.SetDefault("normalization.punctuation", true)
+
+	// Keywords默认值
+	viper.SetDefault("keywords.enabled", true)
+	viper.SetDefault("keywords.method", "tfidf")
+	viper.SetDefault("keywords.top_k", 5)
+	viper.SetDefault("keywords.idf_path", "./data/idf.json")
+	viper.SetDefault("keywords.user_dict", "")
+
+	// Summary默认值
+	viper.SetDefault("summary.enabled", true)
+	viper.SetDefault("summary.max_sentences", 3)
+	viper.SetDefault("summary.max_length", 200)
+
+	// Columns默认值
+	viper.SetDefault("columns.enabled", true)
+	viper.SetDefault("columns.user_dict", "")
+
+	// Assets默认值
+	viper.SetDefault("assets.enabled", false)
+	viper.SetDefault("assets.dir", "./data/assets")
+
+	// Schedule默认值
+	viper.SetDefault("schedule.state_file", "./data/daemon_state.json")
+	viper.SetDefault("schedule.lock_file", "./data/crawl.lock")
+
+	// Distributed默认值
+	viper.SetDefault("distributed.addr", "127.0.0.1:9090")
+	viper.SetDefault("distributed.coordinator_url", "http://127.0.0.1:9090")
+	viper.SetDefault("distributed.lease_duration", "5m")
+	viper.SetDefault("distributed.max_attempts", 3)
+	viper.SetDefault("distributed.poll_interval", "10s")
+	viper.SetDefault("distributed.state_file", "./data/coordinator_state.json")
 }
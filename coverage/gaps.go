@@ -0,0 +1,253 @@
+package coverage
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+)
+
+// 缺口类型
+const (
+	GapMissingDay  = "missing_day"  // 当天没有任何文章
+	GapEditionHole = "edition_hole" // 当天版次不连续
+	GapLowCount    = "low_count"    // 时间段文章数明显少于网站报告总数
+)
+
+// Gap 数据缺口
+type Gap struct {
+	Kind   string    `json:"kind"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Detail string    `json:"detail"`
+}
+
+// Report 覆盖度检查结果
+type Report struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	TotalArticles int       `json:"total_articles"`
+	Gaps          []Gap     `json:"gaps"`
+}
+
+// Options 检查选项
+type Options struct {
+	From time.Time // 检查范围开始日期，零值表示使用最早文章日期
+	To   time.Time // 检查范围结束日期，零值表示使用最晚文章日期
+
+	// ReportedTotals 网站报告的各时间段文章总数，键为 YYYY-MM-DD~YYYY-MM-DD
+	ReportedTotals map[string]int
+
+	// MinRatio 已存储数量低于报告总数的该比例时视为缺口
+	MinRatio float64
+}
+
+// dayCoverage 单日覆盖情况
+type dayCoverage struct {
+	count    int
+	editions map[int]bool
+}
+
+// editionRe 提取版次中的数字
+var editionRe = regexp.MustCompile(`第\s*(\d+)\s*版`)
+
+// Analyze 按发布日期和版次遍历已存储的文章，找出缺失的日期、不连续的版次和数量偏少的时间段
+func Analyze(reader storage.Reader, opts Options) (*Report, error) {
+	days := make(map[string]*dayCoverage)
+	report := &Report{From: opts.From, To: opts.To}
+
+	var earliest, latest time.Time
+	filter := storage.ArticleFilter{From: opts.From, To: opts.To}
+	err := reader.ForEach(filter, func(article *models.Article) error {
+		if article.PublishDate.IsZero() {
+			return nil
+		}
+		report.TotalArticles++
+
+		day := dayOf(article.PublishDate)
+		if earliest.IsZero() || day.Before(earliest) {
+			earliest = day
+		}
+		if day.After(latest) {
+			latest = day
+		}
+
+		key := day.Format("2006-01-02")
+		coverage, exists := days[key]
+		if !exists {
+			coverage = &dayCoverage{editions: make(map[int]bool)}
+			days[key] = coverage
+		}
+		coverage.count++
+		if match := editionRe.FindStringSubmatch(article.Edition); len(match) > 1 {
+			if n, err := strconv.Atoi(match[1]); err == nil {
+				coverage.editions[n] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if report.From.IsZero() {
+		report.From = earliest
+	}
+	if report.To.IsZero() {
+		report.To = latest
+	}
+	if report.From.IsZero() || report.To.IsZero() {
+		return report, nil
+	}
+
+	// 逐日检查缺失日期和版次空洞
+	for day := dayOf(report.From); !day.After(report.To); day = day.AddDate(0, 0, 1) {
+		coverage, exists := days[day.Format("2006-01-02")]
+		if !exists {
+			report.Gaps = append(report.Gaps, Gap{Kind: GapMissingDay, Start: day, End: day, Detail: "当天没有文章"})
+			continue
+		}
+		if missing := missingEditions(coverage.editions); len(missing) > 0 {
+			report.Gaps = append(report.Gaps, Gap{
+				Kind:   GapEditionHole,
+				Start:  day,
+				End:    day,
+				Detail: fmt.Sprintf("缺少版次 %s", formatEditions(missing)),
+			})
+		}
+	}
+
+	// 对照网站报告的时间段总数
+	for key, total := range opts.ReportedTotals {
+		start, end, err := parseRangeKey(key)
+		if err != nil || end.Before(report.From) || start.After(report.To) {
+			continue
+		}
+
+		stored := 0
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			if coverage, exists := days[day.Format("2006-01-02")]; exists {
+				stored += coverage.count
+			}
+		}
+		if float64(stored) < float64(total)*opts.MinRatio {
+			report.Gaps = append(report.Gaps, Gap{
+				Kind:   GapLowCount,
+				Start:  start,
+				End:    end,
+				Detail: fmt.Sprintf("已存储 %d 篇，网站报告 %d 篇", stored, total),
+			})
+		}
+	}
+
+	sort.SliceStable(report.Gaps, func(i, j int) bool {
+		return report.Gaps[i].Start.Before(report.Gaps[j].Start)
+	})
+
+	return report, nil
+}
+
+// Plan 将缺口合并为补抓计划，重叠或相邻的时间段合并为一个
+func (r *Report) Plan() *models.CrawlPlan {
+	plan := &models.CrawlPlan{CreatedAt: time.Now()}
+	if len(r.Gaps) == 0 {
+		return plan
+	}
+
+	gaps := make([]Gap, len(r.Gaps))
+	copy(gaps, r.Gaps)
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return gaps[i].Start.Before(gaps[j].Start)
+	})
+
+	type window struct {
+		start, end time.Time
+		reasons    []string
+	}
+	var windows []*window
+	for _, gap := range gaps {
+		reason := fmt.Sprintf("%s: %s", gap.Kind, gap.Detail)
+		if n := len(windows); n > 0 && !gap.Start.After(windows[n-1].end.AddDate(0, 0, 1)) {
+			last := windows[n-1]
+			if gap.End.After(last.end) {
+				last.end = gap.End
+			}
+			last.reasons = appendUnique(last.reasons, reason)
+			continue
+		}
+		windows = append(windows, &window{start: gap.Start, end: gap.End, reasons: []string{reason}})
+	}
+
+	for _, w := range windows {
+		plan.Windows = append(plan.Windows, models.PlanWindow{
+			Start:  w.start.Format("2006-01-02"),
+			End:    w.end.Format("2006-01-02"),
+			Reason: strings.Join(w.reasons, "; "),
+		})
+	}
+	return plan
+}
+
+// missingEditions 返回1到最大版次之间缺失的版次
+func missingEditions(editions map[int]bool) []int {
+	maxEdition := 0
+	for n := range editions {
+		if n > maxEdition {
+			maxEdition = n
+		}
+	}
+
+	var missing []int
+	for n := 1; n < maxEdition; n++ {
+		if !editions[n] {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+// formatEditions 格式化版次列表，如 第3版、第5版
+func formatEditions(editions []int) string {
+	parts := make([]string, len(editions))
+	for i, n := range editions {
+		parts[i] = fmt.Sprintf("第%d版", n)
+	}
+	return strings.Join(parts, "、")
+}
+
+// parseRangeKey 解析 YYYY-MM-DD~YYYY-MM-DD 格式的时间段键
+func parseRangeKey(key string) (time.Time, time.Time, error) {
+	parts := strings.SplitN(key, "~", 2)
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("时间段格式错误: %s", key)
+	}
+	start, err := time.Parse("2006-01-02", parts[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := time.Parse("2006-01-02", parts[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// dayOf 截取到日期（UTC零点）
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// appendUnique 追加不重复的字符串
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
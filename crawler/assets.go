@@ -0,0 +1,125 @@
+package crawler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// imageExtensions 图片类型对应的文件扩展名
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+}
+
+// AssetDownloader 通过HTTPClient下载文章图片，按内容哈希存储到本地目录
+// 同一张图片只保存一份，路径为 dir/哈希前两位/哈希次两位/哈希.扩展名
+type AssetDownloader struct {
+	client        *HTTPClient
+	dir           string
+	maxRetries    int
+	retryInterval time.Duration
+	wait          func() // 两次下载之间的等待，与抓取文章使用相同的请求间隔
+	requested     bool   // 是否已经发出过请求，第一次下载前不等待
+}
+
+// NewAssetDownloader 创建图片下载器，wait为nil表示下载之间不等待
+func NewAssetDownloader(client *HTTPClient, dir string, maxRetries int, retryInterval time.Duration, wait func()) *AssetDownloader {
+	return &AssetDownloader{
+		client:        client,
+		dir:           dir,
+		maxRetries:    maxRetries,
+		retryInterval: retryInterval,
+		wait:          wait,
+	}
+}
+
+// Init 创建图片目录
+func (d *AssetDownloader) Init() error {
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return fmt.Errorf("创建图片目录失败: %v", err)
+	}
+	return nil
+}
+
+// Download 下载文章中尚未下载的图片并记录本地路径，单张图片失败只记录日志，返回成功下载的数量
+func (d *AssetDownloader) Download(article *models.Article) int {
+	downloaded := 0
+	for i := range article.Images {
+		image := &article.Images[i]
+		if image.LocalPath != "" {
+			continue
+		}
+		if d.requested && d.wait != nil {
+			d.wait()
+		}
+		d.requested = true
+
+		if err := d.fetch(image); err != nil {
+			log.Printf("下载图片失败 [%s]: %v", image.URL, err)
+			continue
+		}
+		downloaded++
+	}
+	return downloaded
+}
+
+// fetch 下载单张图片，内容已存在时不重复写入
+func (d *AssetDownloader) fetch(image *models.ArticleImage) error {
+	body, err := d.client.GetWithRetry(image.URL, d.maxRetries, d.retryInterval)
+	if err != nil {
+		return err
+	}
+
+	contentType := http.DetectContentType(body)
+	if !strings.HasPrefix(contentType, "image/") {
+		return fmt.Errorf("不是图片: %s", contentType)
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+	localPath := filepath.Join(d.dir, hash[:2], hash[2:4], hash+imageExtension(contentType, image.URL))
+
+	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			return fmt.Errorf("创建图片目录失败: %v", err)
+		}
+		// 先写临时文件再重命名，避免中断时留下不完整的图片
+		tmpPath := localPath + ".tmp"
+		if err := os.WriteFile(tmpPath, body, 0644); err != nil {
+			return fmt.Errorf("写入图片失败: %v", err)
+		}
+		if err := os.Rename(tmpPath, localPath); err != nil {
+			return fmt.Errorf("保存图片失败: %v", err)
+		}
+	}
+
+	image.LocalPath = localPath
+	image.SHA256 = hash
+	image.Size = int64(len(body))
+	return nil
+}
+
+// imageExtension 按内容类型确定扩展名，无法识别时使用URL中的扩展名
+func imageExtension(contentType, imageURL string) string {
+	if ext, ok := imageExtensions[contentType]; ok {
+		return ext
+	}
+	ext := strings.ToLower(path.Ext(strings.SplitN(imageURL, "?", 2)[0]))
+	if len(ext) > 1 && len(ext) <= 5 {
+		return ext
+	}
+	return ""
+}
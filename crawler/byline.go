@@ -0,0 +1,238 @@
+package crawler
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// Byline 从副标题和正文开头解析出的署名与电头
+type Byline struct {
+	Reporters []string  // 记者，可能有多人
+	Agency    string    // 发稿机构，如本报、新华社
+	Location  string    // 电头地点，如北京
+	Date      time.Time // 电头日期
+}
+
+// agencyPattern 常见发稿机构
+const agencyPattern = `本报|新华社|中新社|人民日报|经济日报|光明日报|中央广播电视总台|央视网|人民网`
+
+var (
+	// datelineRe 电头，如"本报北京8月29日电"、"新华社华盛顿八月二十八日电"
+	datelineRe = regexp.MustCompile(`^[（(]?(` + agencyPattern + `)(?:驻\p{Han}+?记者)?(\p{Han}{1,12}?)(\d{1,2}|[〇零一二三四五六七八九十]{1,3})月(\d{1,2}|[〇零一二三四五六七八九十]{1,3})日(?:电|讯)`)
+	// newsflashRe 没有地点和日期的电头，如"本报讯"
+	newsflashRe = regexp.MustCompile(`^[（(]?(` + agencyPattern + `)讯`)
+	// signatureAgencyRe 署名中的机构，如"本报记者"、"新华社记者"
+	signatureAgencyRe = regexp.MustCompile(`(` + agencyPattern + `)(?:驻\p{Han}+?)?记者`)
+	// signatureEndRe 以机构署名结尾，用于判断"记者"是否属于署名
+	signatureEndRe = regexp.MustCompile(`(` + agencyPattern + `)(?:驻\p{Han}+?)?记者$`)
+)
+
+// bylineHeadLength 在正文开头多少个字符内查找电头和署名
+const bylineHeadLength = 120
+
+// nameSuffixes 署名后常见的动词，需要从姓名中去掉
+var nameSuffixes = []string{"报道", "摄影", "采写", "整理", "撰文", "发自", "摄", "文"}
+
+// narrativeVerbs 正文叙述中"记者"后常见的动词，以这些词开头的不是姓名
+var narrativeVerbs = []string{"看到", "了解到", "获悉", "注意到", "发现"}
+
+// ParseByline 从副标题和正文开头解析记者、发稿机构和电头
+// publishDate用于补全电头日期的年份
+func ParseByline(subtitle, content string, publishDate time.Time) Byline {
+	var byline Byline
+
+	head := strings.TrimLeftFunc(content, unicode.IsSpace)
+	if utf8.RuneCountInString(head) > bylineHeadLength {
+		head = string([]rune(head)[:bylineHeadLength])
+	}
+
+	if match := datelineRe.FindStringSubmatch(head); match != nil {
+		byline.Agency = match[1]
+		byline.Location = match[2]
+		byline.Date = datelineDate(parseNumeral(match[3]), parseNumeral(match[4]), publishDate)
+	} else if match := newsflashRe.FindStringSubmatch(head); match != nil {
+		byline.Agency = match[1]
+	}
+
+	for _, text := range []string{subtitle, head} {
+		if byline.Agency != "" {
+			break
+		}
+		if match := signatureAgencyRe.FindStringSubmatch(text); match != nil {
+			byline.Agency = match[1]
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, text := range []string{subtitle, head} {
+		for _, name := range parseReporters(text, i == 0) {
+			if !seen[name] {
+				seen[name] = true
+				byline.Reporters = append(byline.Reporters, name)
+			}
+		}
+	}
+
+	return byline
+}
+
+// ApplyByline 解析署名与电头并写入文章
+func ApplyByline(article *models.Article) {
+	byline := ParseByline(article.Subtitle, article.Content, article.PublishDate)
+	article.Reporters = byline.Reporters
+	article.Agency = byline.Agency
+	article.DatelineLocation = byline.Location
+	article.DatelineDate = byline.Date
+}
+
+// datelineDate 电头只有月日，年份取自发布日期；电头月份晚于发布月份时视为上一年
+func datelineDate(month, day int, publishDate time.Time) time.Time {
+	if month < 1 || month > 12 || day < 1 || day > 31 || publishDate.IsZero() {
+		return time.Time{}
+	}
+
+	year := publishDate.Year()
+	if month > int(publishDate.Month()) {
+		year--
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, publishDate.Location())
+	if date.Day() != day {
+		return time.Time{}
+	}
+	return date
+}
+
+// parseReporters 查找"记者"后面的姓名，姓名之间以顿号、逗号或空格分隔
+// subtitle为true时text是副标题，整段都是署名，对"记者"的位置要求较宽
+func parseReporters(text string, subtitle bool) []string {
+	var names []string
+	runes := []rune(text)
+	marker := []rune("记者")
+
+	for i := 0; i+len(marker) <= len(runes); i++ {
+		if string(runes[i:i+len(marker)]) != string(marker) || !isSignatureStart(runes, i, subtitle) {
+			continue
+		}
+
+		pos := skipRunes(runes, i+len(marker), func(r rune) bool { return r == '：' || r == ':' || unicode.IsSpace(r) })
+		for pos < len(runes) {
+			end := pos
+			for end < len(runes) && (unicode.Is(unicode.Han, runes[end]) || runes[end] == '·') {
+				end++
+			}
+			name, ok := cleanName(string(runes[pos:end]))
+			if !ok || !isNameEnd(runes, end, name != string(runes[pos:end])) {
+				break
+			}
+			names = append(names, name)
+
+			// 顿号、逗号之后一定是下一个姓名；空格之后只有短词且紧跟分隔符时才是姓名
+			next := skipRunes(runes, end, unicode.IsSpace)
+			if next < len(runes) && strings.ContainsRune("、，,", runes[next]) {
+				pos = skipRunes(runes, next+1, unicode.IsSpace)
+				continue
+			}
+			if next > end && next < len(runes) && isShortNameAt(runes, next) {
+				pos = next
+				continue
+			}
+			break
+		}
+		i = pos - 1
+	}
+
+	return names
+}
+
+// isSignatureStart 署名中的"记者"紧跟发稿机构、驻外地点，或位于括号内、电头之后；
+// 副标题中还可以位于开头、空白或分隔符之后。用于排除正文中"，记者看到"之类的叙述
+func isSignatureStart(runes []rune, i int, subtitle bool) bool {
+	if signatureEndRe.MatchString(string(runes[:i+2])) {
+		return true
+	}
+	if subtitle && (i == 0 || unicode.IsSpace(runes[i-1]) || strings.ContainsRune("、，,：:", runes[i-1])) {
+		return true
+	}
+	return inParentheses(runes[:i]) || afterDateline(runes[:i])
+}
+
+// inParentheses 判断文本末尾是否处在未闭合的括号内
+func inParentheses(runes []rune) bool {
+	depth := 0
+	for _, r := range runes {
+		switch r {
+		case '（', '(':
+			depth++
+		case '）', ')':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	return depth > 0
+}
+
+// afterDateline 判断去掉末尾空白和分隔符后，文本是否恰好是一个电头
+func afterDateline(runes []rune) bool {
+	text := strings.TrimRightFunc(string(runes), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("、，,：:", r)
+	})
+	for _, re := range []*regexp.Regexp{datelineRe, newsflashRe} {
+		if loc := re.FindStringIndex(text); loc != nil && loc[1] == len(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNameEnd 姓名之后必须是结尾、空白、分隔符或右括号；去掉过署名动词的姓名总是有效
+func isNameEnd(runes []rune, end int, trimmed bool) bool {
+	return trimmed || end == len(runes) || unicode.IsSpace(runes[end]) || strings.ContainsRune("、，,）)：:", runes[end])
+}
+
+// cleanName 去掉署名后的动词，姓名为2到4个字（少数民族姓名可含间隔号）
+// 以叙述动词开头的不是姓名
+func cleanName(name string) (string, bool) {
+	for _, verb := range narrativeVerbs {
+		if strings.HasPrefix(name, verb) {
+			return name, false
+		}
+	}
+	for _, suffix := range nameSuffixes {
+		if trimmed := strings.TrimSuffix(name, suffix); trimmed != name && utf8.RuneCountInString(trimmed) >= 2 {
+			name = trimmed
+			break
+		}
+	}
+
+	length := utf8.RuneCountInString(name)
+	if strings.ContainsRune(name, '·') {
+		return name, length >= 3 && length <= 12
+	}
+	return name, length >= 2 && length <= 4
+}
+
+// isShortNameAt 判断位置i开始的是否为一个以分隔符或括号结尾的2到3字的词
+func isShortNameAt(runes []rune, i int) bool {
+	end := i
+	for end < len(runes) && unicode.Is(unicode.Han, runes[end]) {
+		end++
+	}
+	if length := end - i; length < 2 || length > 3 {
+		return false
+	}
+	return end == len(runes) || unicode.IsSpace(runes[end]) || strings.ContainsRune("、，,）)", runes[end])
+}
+
+// skipRunes 跳过满足条件的字符，返回第一个不满足的位置
+func skipRunes(runes []rune, i int, skip func(rune) bool) int {
+	for i < len(runes) && skip(runes[i]) {
+		i++
+	}
+	return i
+}
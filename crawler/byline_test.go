@@ -0,0 +1,70 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+func TestApplyByline(t *testing.T) {
+	publishDate := time.Date(2025, 8, 30, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		subtitle  string
+		content   string
+		reporters string
+		agency    string
+		location  string
+		date      string
+	}{
+		{"电头带机构和日期", "", "本报北京8月29日电  国务院常务会议部署有关工作。",
+			"", "本报", "北京", "2025-08-29"},
+		{"中文数字电头", "", "新华社华盛顿八月二十八日电  美国政府宣布……",
+			"", "新华社", "华盛顿", "2025-08-28"},
+		{"电头月份晚于发布月份为上一年", "", "本报上海12月31日电  新年前夕……",
+			"", "本报", "上海", "2024-12-31"},
+		{"本报讯", "", "本报讯  记者从有关部门获悉……",
+			"", "本报", "", ""},
+		{"副标题中多名记者", "本报记者  张三、李四，王五", "会议指出……",
+			"张三,李四,王五", "本报", "", ""},
+		{"副标题中空格分隔的记者", "本报记者 张三 李四", "会议指出……",
+			"张三,李四", "本报", "", ""},
+		{"署名动词", "新华社记者 赵六摄", "图为……",
+			"赵六", "新华社", "", ""},
+		{"少数民族姓名", "本报记者 阿依古丽·买买提", "……",
+			"阿依古丽·买买提", "本报", "", ""},
+		{"括号中的署名", "", "本报北京8月29日电  （记者张三、李四）国务院常务会议部署有关工作。",
+			"张三,李四", "本报", "北京", "2025-08-29"},
+		{"电头后的署名", "", "新华社北京8月29日电  记者王五报道：会议指出……",
+			"王五", "新华社", "北京", "2025-08-29"},
+		{"正文叙述中的记者不是署名", "", "在这里，记者看到工人们正在忙碌。记者了解到，项目进展顺利。",
+			"", "", "", ""},
+		{"副标题和正文中的署名去重", "本报记者 张三", "本报北京8月29日电  （记者张三）会议指出……",
+			"张三", "本报", "北京", "2025-08-29"},
+		{"没有署名", "", "会议指出……", "", "", "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			article := &models.Article{Subtitle: tc.subtitle, Content: tc.content, PublishDate: publishDate}
+			ApplyByline(article)
+
+			if got := strings.Join(article.Reporters, ","); got != tc.reporters {
+				t.Errorf("记者 = %q, 期望 %q", got, tc.reporters)
+			}
+			if article.Agency != tc.agency || article.DatelineLocation != tc.location {
+				t.Errorf("机构和地点 = %q %q, 期望 %q %q", article.Agency, article.DatelineLocation, tc.agency, tc.location)
+			}
+			date := ""
+			if !article.DatelineDate.IsZero() {
+				date = article.DatelineDate.Format("2006-01-02")
+			}
+			if date != tc.date {
+				t.Errorf("电头日期 = %q, 期望 %q", date, tc.date)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package crawler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FeatureLine 从特征内容中解析出的报名、日期、版次和版面类型
+// 例如"【人民日报2025年8月30日 第1版 要闻】"、"人民日报一九四九年十月一日 第一版"
+type FeatureLine struct {
+	Newspaper  string    // 报名，如人民日报
+	Date       time.Time // 出版日期
+	Edition    int       // 版次，0表示未解析到
+	Type       string    // 版面类型，如要闻，可能是空的
+	Confidence float64   // 解析可信度，0到1
+}
+
+// EditionName 版次的规范写法，如第1版
+func (f FeatureLine) EditionName() string {
+	if f.Edition <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("第%d版", f.Edition)
+}
+
+// 各字段在可信度中的权重，日期和版次最重要
+const (
+	featureDateWeight      = 0.45
+	featureEditionWeight   = 0.35
+	featureNewspaperWeight = 0.1
+	featureTypeWeight      = 0.1
+	// featureAmbiguityPenalty 出现多个日期或版次时扣除的可信度
+	featureAmbiguityPenalty = 0.2
+)
+
+// numeralClass 阿拉伯数字和中文数字
+const numeralClass = `[0-9〇○零一二三四五六七八九十百两]`
+
+var (
+	// featureDateRe 日期，如2025年8月30日、一九四九年十月一日、2025-08-30
+	featureDateRe = regexp.MustCompile(`(` + numeralClass + `{4})\s*年\s*(` + numeralClass + `{1,3})\s*月\s*(` + numeralClass + `{1,3})\s*日` +
+		`|(\d{4})[-./](\d{1,2})[-./](\d{1,2})`)
+	// featureEditionRe 版次，如第1版、第 01 版、第十二版
+	featureEditionRe = regexp.MustCompile(`第\s*(` + numeralClass + `{1,4})\s*版`)
+	// featureFrontPageRe 头版
+	featureFrontPageRe = regexp.MustCompile(`头\s*版`)
+	// featureFontSizeRe 页面上的字号控件
+	featureFontSizeRe = regexp.MustCompile(`【字号[^】]*】`)
+	// featureNewspaperRe 日期之前的报名
+	featureNewspaperRe = regexp.MustCompile(`(\p{Han}{2,8}(?:报(?:海外版)?|刊))\s*[》）)]?\s*[（(]?\s*$`)
+)
+
+// featureBrackets 特征内容中出现的各种括号和分隔符
+const featureBrackets = "【】《》（）()[]「」"
+
+// ParseFeatureLine 解析特征内容，支持阿拉伯数字和中文数字、有无【】括号以及字段缺失的写法
+func ParseFeatureLine(raw string) FeatureLine {
+	var feature FeatureLine
+
+	text := featureFontSizeRe.ReplaceAllString(toHalfWidthDigits(raw), " ")
+	penalty := 0.0
+
+	dateMatches := featureDateRe.FindAllStringSubmatchIndex(text, -1)
+	dateEnd := -1
+	if len(dateMatches) > 0 {
+		match := dateMatches[0]
+		feature.Date = featureDate(text, match)
+		if !feature.Date.IsZero() {
+			dateEnd = match[1]
+			feature.Newspaper = featureNewspaper(text[:match[0]])
+		}
+		if len(dateMatches) > 1 {
+			penalty += featureAmbiguityPenalty
+		}
+	}
+
+	editionMatches := featureEditionRe.FindAllStringSubmatchIndex(text, -1)
+	editionEnd := -1
+	if len(editionMatches) > 0 {
+		match := editionMatches[0]
+		if edition := parseNumeral(text[match[2]:match[3]]); edition > 0 && edition < 1000 {
+			feature.Edition = edition
+			editionEnd = match[1]
+		}
+		if len(editionMatches) > 1 {
+			penalty += featureAmbiguityPenalty
+		}
+	} else if loc := featureFrontPageRe.FindStringIndex(text); loc != nil {
+		feature.Edition = 1
+		editionEnd = loc[1]
+	}
+
+	// 版面类型在版次之后；没有版次时在日期之后
+	switch {
+	case editionEnd >= 0:
+		feature.Type = featureType(text[editionEnd:])
+	case dateEnd >= 0:
+		feature.Type = featureType(text[dateEnd:])
+	}
+
+	if !feature.Date.IsZero() {
+		feature.Confidence += featureDateWeight
+	}
+	if feature.Edition > 0 {
+		feature.Confidence += featureEditionWeight
+	}
+	if feature.Newspaper != "" {
+		feature.Confidence += featureNewspaperWeight
+	}
+	if feature.Type != "" {
+		feature.Confidence += featureTypeWeight
+	}
+	feature.Confidence -= penalty
+	if feature.Confidence < 0 {
+		feature.Confidence = 0
+	}
+
+	return feature
+}
+
+// featureDate 按匹配到的写法解析日期，非法日期返回零值
+func featureDate(text string, match []int) time.Time {
+	group := func(i int) string {
+		if match[2*i] < 0 {
+			return ""
+		}
+		return text[match[2*i]:match[2*i+1]]
+	}
+
+	year, month, day := parseNumeral(group(1)), parseNumeral(group(2)), parseNumeral(group(3))
+	if group(1) == "" {
+		year, month, day = parseNumeral(group(4)), parseNumeral(group(5)), parseNumeral(group(6))
+	}
+	if year < 1900 || month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return time.Time{}
+	}
+	return date
+}
+
+// featureNewspaper 取日期之前紧挨着的报名
+func featureNewspaper(prefix string) string {
+	if match := featureNewspaperRe.FindStringSubmatch(prefix); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// featureType 取版次之后第一个词作为版面类型，遇到右括号或字号控件为止
+func featureType(rest string) string {
+	if end := strings.IndexAny(rest, "】）)]」"); end >= 0 {
+		rest = rest[:end]
+	}
+	rest = strings.TrimLeft(rest, " \t\r\n　:：·-—"+featureBrackets)
+
+	fields := strings.FieldsFunc(rest, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(featureBrackets, r)
+	})
+	if len(fields) == 0 {
+		return ""
+	}
+
+	typ := strings.TrimRight(fields[0], "：:")
+	if len([]rune(typ)) > 20 || strings.Contains(typ, "字号") || strings.Contains(typ, "版") {
+		return ""
+	}
+	for _, r := range typ {
+		if !unicode.Is(unicode.Han, r) && !unicode.IsLetter(r) && !strings.ContainsRune("·、", r) {
+			return ""
+		}
+	}
+	return typ
+}
+
+// toHalfWidthDigits 将全角数字转换为半角
+func toHalfWidthDigits(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '０' && r <= '９' {
+			return r - '０' + '0'
+		}
+		return r
+	}, text)
+}
+
+// chineseDigits 中文数字对应的值，○和〇都常用作零
+var chineseDigits = map[rune]int{
+	'〇': 0, '○': 0, '零': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+// parseNumeral 解析阿拉伯数字或中文数字，支持逐位写法（一九四九、二〇二五）和带十、百的写法（二十八、一百零二），无法解析时返回0
+func parseNumeral(value string) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if !strings.ContainsAny(value, "十百") {
+		n := 0
+		for _, r := range value {
+			switch digit, ok := chineseDigits[r]; {
+			case r >= '0' && r <= '9':
+				n = n*10 + int(r-'0')
+			case ok:
+				n = n*10 + digit
+			default:
+				return 0
+			}
+		}
+		return n
+	}
+
+	total, current := 0, 0
+	for _, r := range value {
+		switch r {
+		case '十':
+			if current == 0 {
+				current = 1
+			}
+			total += current * 10
+			current = 0
+		case '百':
+			if current == 0 {
+				current = 1
+			}
+			total += current * 100
+			current = 0
+		default:
+			digit, ok := chineseDigits[r]
+			if !ok {
+				return 0
+			}
+			current = digit
+		}
+	}
+	return total + current
+}
@@ -0,0 +1,98 @@
+package crawler
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// featureCase testdata/feature_lines.tsv 中的一条样例
+type featureCase struct {
+	line          int
+	raw           string
+	date          string
+	edition       string
+	typ           string
+	minConfidence float64
+}
+
+// loadFeatureCases 读取样例文件，跳过空行和#开头的注释
+func loadFeatureCases(t *testing.T, path string) []featureCase {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("打开样例文件失败: %v", err)
+	}
+	defer file.Close()
+
+	unescape := strings.NewReplacer(`\n`, "\n", `\t`, "\t")
+
+	var cases []featureCase
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 5 {
+			t.Fatalf("样例第%d行应有5列，实际%d列", lineNo, len(fields))
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		minConfidence, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			t.Fatalf("样例第%d行可信度无效: %v", lineNo, err)
+		}
+
+		cases = append(cases, featureCase{
+			line:          lineNo,
+			raw:           unescape.Replace(fields[0]),
+			date:          fields[1],
+			edition:       fields[2],
+			typ:           fields[3],
+			minConfidence: minConfidence,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("读取样例文件失败: %v", err)
+	}
+	return cases
+}
+
+func TestParseFeatureLine(t *testing.T) {
+	for _, tc := range loadFeatureCases(t, "testdata/feature_lines.tsv") {
+		tc := tc
+		t.Run(strconv.Itoa(tc.line), func(t *testing.T) {
+			feature := ParseFeatureLine(tc.raw)
+
+			date := ""
+			if !feature.Date.IsZero() {
+				date = feature.Date.Format("2006-01-02")
+			}
+			if date != tc.date {
+				t.Errorf("日期 %q != %q: %q", date, tc.date, tc.raw)
+			}
+			edition := ""
+			if feature.Edition > 0 {
+				edition = strconv.Itoa(feature.Edition)
+			}
+			if edition != tc.edition {
+				t.Errorf("版次 %q != %q: %q", edition, tc.edition, tc.raw)
+			}
+			if feature.Type != tc.typ {
+				t.Errorf("类型 %q != %q: %q", feature.Type, tc.typ, tc.raw)
+			}
+			if feature.Confidence+1e-9 < tc.minConfidence {
+				t.Errorf("可信度 %.2f < %.2f: %q", feature.Confidence, tc.minConfidence, tc.raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package crawler
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+// maxCaptionLength 图注最多字数，超过的相邻段落视为正文而不是图注
+const maxCaptionLength = 200
+
+// extractImages 收集各div中的图片及其图注，按出现顺序去重
+func extractImages(divs []*html.Node) []models.ArticleImage {
+	var images []models.ArticleImage
+	seen := make(map[string]bool)
+
+	for _, div := range divs {
+		for _, img := range htmlquery.Find(div, ".//img") {
+			src := strings.TrimSpace(imageSource(img))
+			if src == "" || strings.HasPrefix(src, "data:") || seen[src] {
+				continue
+			}
+			seen[src] = true
+			images = append(images, models.ArticleImage{
+				URL:     src,
+				Caption: imageCaption(img),
+			})
+		}
+	}
+
+	return images
+}
+
+// imageSource 图片地址，懒加载的图片地址在data-src等属性中
+func imageSource(img *html.Node) string {
+	for _, attr := range []string{"src", "data-src", "data-original"} {
+		if value := htmlquery.SelectAttr(img, attr); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// imageCaption 查找图片附近的图注：figure中的figcaption、图片所在段落的文字、紧随其后的短段落，最后是alt和title属性
+func imageCaption(img *html.Node) string {
+	if figure := closestAncestor(img, "figure"); figure != nil {
+		if caption := htmlquery.FindOne(figure, ".//figcaption"); caption != nil {
+			if text := cleanCaption(htmlquery.InnerText(caption)); text != "" {
+				return text
+			}
+		}
+	}
+
+	if block := closestAncestor(img, "p", "td", "center"); block != nil {
+		if text := cleanCaption(htmlquery.InnerText(block)); isCaption(text) {
+			return text
+		}
+		if next := nextElementSibling(block); next != nil && htmlquery.FindOne(next, ".//img") == nil {
+			if text := cleanCaption(htmlquery.InnerText(next)); isCaption(text) {
+				return text
+			}
+		}
+	}
+
+	for _, attr := range []string{"alt", "title"} {
+		if text := cleanCaption(htmlquery.SelectAttr(img, attr)); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// isCaption 图注是不太长的非空文字
+func isCaption(text string) bool {
+	return text != "" && utf8.RuneCountInString(text) <= maxCaptionLength
+}
+
+// cleanCaption 合并图注中的空白
+func cleanCaption(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// closestAncestor 最近的指定标签的祖先节点
+func closestAncestor(node *html.Node, tags ...string) *html.Node {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent.Type != html.ElementNode {
+			continue
+		}
+		for _, tag := range tags {
+			if parent.Data == tag {
+				return parent
+			}
+		}
+	}
+	return nil
+}
+
+// nextElementSibling 下一个元素兄弟节点
+func nextElementSibling(node *html.Node) *html.Node {
+	for sibling := node.NextSibling; sibling != nil; sibling = sibling.NextSibling {
+		if sibling.Type == html.ElementNode {
+			return sibling
+		}
+	}
+	return nil
+}
+
+// ResolveImageURLs 将文章图片的相对地址解析为基于文章URL的绝对地址
+func ResolveImageURLs(article *models.Article, pageURL string) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return
+	}
+	for i := range article.Images {
+		if ref, err := url.Parse(article.Images[i].URL); err == nil {
+			article.Images[i].URL = base.ResolveReference(ref).String()
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+var (
+	// layoutPageRe 版面链接，如 node_01.html
+	layoutPageRe = regexp.MustCompile(`node_(\d+)\.html?$`)
+	// layoutArticleRe 文章链接，如 content_30090001.html
+	layoutArticleRe = regexp.MustCompile(`content_\d+\.html?$`)
+	// layoutPageNameRe 版面名称，如 "01版：要闻"、"第01版：要闻"
+	layoutPageNameRe = regexp.MustCompile(`^第?\s*\d+\s*版\s*[：:]?\s*`)
+)
+
+// ParseLayoutPage 解析版面页，返回当天的报纸（版面不含文章）以及当前版面和其中的文章
+// 版面列表取自页面中的node_XX链接，文章顺序以标题列表为准，热区坐标取自版面图的area
+func (p *Parser) ParseLayoutPage(body []byte, pageURL string, date time.Time) (*models.Issue, *models.Page, error) {
+	doc, err := htmlquery.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析版面页失败: %v", err)
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析版面URL失败: %v", err)
+	}
+
+	issue := &models.Issue{Date: date}
+	seenPages := make(map[int]bool)
+	current := &models.Page{URL: pageURL}
+	if match := layoutPageRe.FindStringSubmatch(base.Path); match != nil {
+		current.Edition, _ = strconv.Atoi(match[1])
+	}
+
+	var (
+		links, areaLinks []models.PageArticle
+		coords           = make(map[string]string)
+		seenLinks        = make(map[string]bool)
+	)
+
+	for _, node := range htmlquery.Find(doc, "//a[@href] | //area[@href]") {
+		href := resolveLayoutURL(base, htmlquery.SelectAttr(node, "href"))
+		if href == "" {
+			continue
+		}
+
+		if match := layoutPageRe.FindStringSubmatch(href); match != nil && node.Data == "a" {
+			number, _ := strconv.Atoi(match[1])
+			if number == 0 || seenPages[number] {
+				continue
+			}
+			seenPages[number] = true
+			name := layoutPageName(cleanCaption(htmlquery.InnerText(node)))
+			issue.Pages = append(issue.Pages, models.Page{Edition: number, Name: name, URL: href})
+			if number == current.Edition {
+				current.Name = name
+			}
+			continue
+		}
+
+		if strings.HasSuffix(strings.ToLower(href), ".pdf") {
+			if current.PDFURL == "" {
+				current.PDFURL = href
+			}
+			continue
+		}
+		if !layoutArticleRe.MatchString(href) {
+			continue
+		}
+		if node.Data == "area" {
+			if _, ok := coords[href]; !ok {
+				coords[href] = strings.Join(strings.Fields(htmlquery.SelectAttr(node, "coords")), "")
+				areaLinks = append(areaLinks, models.PageArticle{URL: href})
+			}
+			continue
+		}
+		title := cleanCaption(htmlquery.InnerText(node))
+		if title == "" || seenLinks[href] {
+			continue
+		}
+		seenLinks[href] = true
+		links = append(links, models.PageArticle{URL: href, Title: title})
+	}
+
+	// 只在版面图中出现的文章排在标题列表之后
+	for _, link := range areaLinks {
+		if !seenLinks[link.URL] {
+			seenLinks[link.URL] = true
+			links = append(links, link)
+		}
+	}
+	for i := range links {
+		links[i].Ordinal = i + 1
+		links[i].Coords = coords[links[i].URL]
+	}
+	current.Articles = links
+
+	// 页面中没有版面导航时，至少包含当前版面
+	if len(issue.Pages) == 0 && current.Edition > 0 {
+		issue.Pages = append(issue.Pages, models.Page{Edition: current.Edition, Name: current.Name, URL: pageURL})
+	}
+	sort.Slice(issue.Pages, func(i, j int) bool {
+		return issue.Pages[i].Edition < issue.Pages[j].Edition
+	})
+	issue.TotalEditions = len(issue.Pages)
+
+	return issue, current, nil
+}
+
+// ParseLayoutArticle 解析电子版文章页，版次、版面名称和发布日期由版面提供
+// 正文不在电子版的常见容器中时，按检索结果页的结构解析
+func (p *Parser) ParseLayoutArticle(body []byte, date time.Time, page *models.Page, link models.PageArticle) (*models.Article, error) {
+	doc, err := htmlquery.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("解析文章页失败: %v", err)
+	}
+
+	container := htmlquery.FindOne(doc, "//div[@id='ozoom'] | //div[@id='articleContent']")
+	var article *models.Article
+	if container == nil {
+		if article, err = p.ParseHTMLStructure(string(body)); err != nil {
+			return nil, err
+		}
+	} else {
+		article = parseLayoutArticleBody(doc, container)
+	}
+
+	if article.Title == "" {
+		article.Title = link.Title
+	}
+	article.URL = link.URL
+	if article.Permalink == "" {
+		article.Permalink = link.URL
+	}
+	article.Ordinal = link.Ordinal
+	article.PageCoords = link.Coords
+
+	// 特征内容按检索结果页的格式拼接，与其他抓取方式保持一致
+	if article.Raw == "" {
+		article.Raw = strings.TrimSpace(fmt.Sprintf("人民日报%d年%d月%d日 第%d版 %s",
+			date.Year(), int(date.Month()), date.Day(), page.Edition, page.Name))
+		p.parseFeatureFields(article.Raw, article)
+	}
+	if article.PublishDate.IsZero() {
+		article.PublishDate = date
+	}
+
+	ResolveImageURLs(article, link.URL)
+	ApplyByline(article)
+	if article.CreatedAt.IsZero() {
+		article.CreatedAt = time.Now()
+	}
+	return article, nil
+}
+
+// parseLayoutArticleBody 解析电子版文章页：h3引题、h1标题、h2副题，作者行在h4或class为sec的段落中
+func parseLayoutArticleBody(doc, container *html.Node) *models.Article {
+	article := &models.Article{Permalink: findPermalink(doc)}
+
+	text := func(expr string) string {
+		if node := htmlquery.FindOne(doc, expr); node != nil {
+			return strings.TrimSpace(htmlquery.InnerText(node))
+		}
+		return ""
+	}
+	article.Title = text("//h1")
+
+	var subtitles []string
+	for _, expr := range []string{"//h2", "//h4", "//p[@class='sec']"} {
+		if value := cleanCaption(text(expr)); value != "" {
+			subtitles = append(subtitles, value)
+		}
+	}
+	article.Subtitle = strings.Join(subtitles, " ")
+
+	var paragraphs []string
+	for _, node := range htmlquery.Find(container, ".//p") {
+		if value := strings.TrimSpace(htmlquery.InnerText(node)); value != "" {
+			paragraphs = append(paragraphs, value)
+		}
+	}
+	if len(paragraphs) == 0 {
+		if value := strings.TrimSpace(htmlquery.InnerText(container)); value != "" {
+			paragraphs = append(paragraphs, value)
+		}
+	}
+	article.Content = strings.Join(paragraphs, "\n\n")
+	article.ContentMarkdown = RenderMarkdown([]*html.Node{container})
+
+	// 电子版的图片在正文容器之前的表格中
+	scope := container
+	if parent := closestAncestor(container, "div"); parent != nil {
+		scope = parent
+	}
+	article.Images = extractImages([]*html.Node{scope})
+	article.Tables = extractTables([]*html.Node{container})
+
+	return article
+}
+
+// resolveLayoutURL 将版面中的相对链接解析为绝对地址，去掉锚点
+func resolveLayoutURL(base *url.URL, href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "javascript:") || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	resolved := base.ResolveReference(ref)
+	resolved.Fragment = ""
+	return resolved.String()
+}
+
+// layoutPageName 去掉版面链接文字中的版次，如 "01版：要闻" 得到 "要闻"
+func layoutPageName(text string) string {
+	return strings.TrimSpace(layoutPageNameRe.ReplaceAllString(text, ""))
+}
@@ -0,0 +1,191 @@
+package crawler
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+const layoutBase = "https://paper.people.com.cn/rmrb/html/2025-08/30/"
+
+// readLayoutFixture 读取 testdata/layout 下的页面
+func readLayoutFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile("testdata/layout/" + name)
+	if err != nil {
+		t.Fatalf("读取样例页面失败: %v", err)
+	}
+	return body
+}
+
+// formatPages 版面列表格式化为 "版次:名称"
+func formatPages(pages []models.Page) []string {
+	var result []string
+	for _, page := range pages {
+		result = append(result, strconv.Itoa(page.Edition)+":"+page.Name)
+	}
+	return result
+}
+
+// formatLinks 版面中的文章格式化为 "顺序|文件名|标题|坐标"
+func formatLinks(links []models.PageArticle) []string {
+	var result []string
+	for _, link := range links {
+		name := link.URL[strings.LastIndex(link.URL, "/")+1:]
+		result = append(result, strings.Join([]string{strconv.Itoa(link.Ordinal), name, link.Title, link.Coords}, "|"))
+	}
+	return result
+}
+
+func TestParseLayoutPage(t *testing.T) {
+	cases := []struct {
+		name     string
+		fixture  string
+		edition  int
+		pages    []string
+		pageName string
+		pdf      string
+		articles []string
+	}{
+		{
+			name:     "第1版",
+			fixture:  "node_01.html",
+			edition:  1,
+			pages:    []string{"1:要闻", "2:要闻", "4:国际"}, // 重复的第1版和版次为0的链接不计入
+			pageName: "要闻",
+			pdf:      "https://paper.people.com.cn/rmrb/attachement/202508/30/rmrb2025083001.pdf",
+			articles: []string{
+				// 标题列表的顺序在前，只在版面图中出现的文章排在后面；坐标去掉空白，链接去掉锚点
+				"1|content_30090002.html|国务院常务会议 部署有关工作|310,20,600,400",
+				"2|content_30090001.html|习近平会见外宾|10,20,300,400",
+				"3|content_30090003.html||10,410,300,800",
+			},
+		},
+		{
+			name:     "没有版面导航",
+			fixture:  "node_03.html",
+			edition:  3,
+			pages:    []string{"3:"},
+			pageName: "",
+			pdf:      "",
+			articles: []string{"1|content_30090031.html|评论员文章|"},
+		},
+	}
+
+	parser := NewParser(nil)
+	date := time.Date(2025, 8, 30, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			issue, page, err := parser.ParseLayoutPage(readLayoutFixture(t, tc.fixture), layoutBase+tc.fixture, date)
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+
+			if got := strings.Join(formatPages(issue.Pages), ","); got != strings.Join(tc.pages, ",") {
+				t.Errorf("版面 = %s, 期望 %s", got, strings.Join(tc.pages, ","))
+			}
+			if issue.TotalEditions != len(tc.pages) {
+				t.Errorf("版面数 = %d, 期望 %d", issue.TotalEditions, len(tc.pages))
+			}
+			if page.Edition != tc.edition || page.Name != tc.pageName {
+				t.Errorf("当前版面 = %d %q, 期望 %d %q", page.Edition, page.Name, tc.edition, tc.pageName)
+			}
+			if page.PDFURL != tc.pdf {
+				t.Errorf("PDF = %q, 期望 %q", page.PDFURL, tc.pdf)
+			}
+
+			got := formatLinks(page.Articles)
+			if strings.Join(got, "\n") != strings.Join(tc.articles, "\n") {
+				t.Errorf("文章 =\n%s\n期望\n%s", strings.Join(got, "\n"), strings.Join(tc.articles, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseLayoutArticle(t *testing.T) {
+	cases := []struct {
+		name      string
+		fixture   string
+		link      models.PageArticle
+		title     string
+		permalink string
+		content   string
+		reporters string
+	}{
+		{
+			name:      "电子版正文",
+			fixture:   "content.html",
+			link:      models.PageArticle{URL: layoutBase + "content_30090001.html", Title: "链接标题", Ordinal: 2, Coords: "10,20,300,400"},
+			title:     "习近平会见外宾",
+			permalink: "http://paper.people.com.cn/rmrb/html/2025-08/30/nw.D110000renmrb_20250830_1-01.htm",
+			content:   "本报北京8月29日电  会见在人民大会堂举行。\n\n双方就共同关心的问题交换了意见。",
+			reporters: "张三",
+		},
+		{
+			name:      "标题和固定链接取自版面",
+			fixture:   "content_untitled.html",
+			link:      models.PageArticle{URL: layoutBase + "content_30090003.html", Title: "图片新闻", Ordinal: 3, Coords: "10,410,300,800"},
+			title:     "图片新闻",
+			permalink: layoutBase + "content_30090003.html",
+			content:   "图片新闻说明文字",
+			reporters: "",
+		},
+	}
+
+	parser := NewParser(nil)
+	date := time.Date(2025, 8, 30, 0, 0, 0, 0, time.UTC)
+	page := &models.Page{Edition: 1, Name: "要闻"}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			article, err := parser.ParseLayoutArticle(readLayoutFixture(t, tc.fixture), date, page, tc.link)
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+
+			if article.Title != tc.title {
+				t.Errorf("标题 = %q, 期望 %q", article.Title, tc.title)
+			}
+			if article.URL != tc.link.URL || article.Permalink != tc.permalink {
+				t.Errorf("链接 = %q %q, 期望 %q %q", article.URL, article.Permalink, tc.link.URL, tc.permalink)
+			}
+			if article.Content != tc.content {
+				t.Errorf("正文 = %q, 期望 %q", article.Content, tc.content)
+			}
+			if article.Ordinal != tc.link.Ordinal || article.PageCoords != tc.link.Coords {
+				t.Errorf("版面位置 = %d %q, 期望 %d %q", article.Ordinal, article.PageCoords, tc.link.Ordinal, tc.link.Coords)
+			}
+			// 版次、类型和日期来自版面
+			if article.Edition != "第1版" || article.Type != "要闻" || !article.PublishDate.Equal(date) {
+				t.Errorf("特征内容 = %s %s %v, 期望 第1版 要闻 %v", article.Edition, article.Type, article.PublishDate, date)
+			}
+			if got := strings.Join(article.Reporters, ","); got != tc.reporters {
+				t.Errorf("记者 = %q, 期望 %q", got, tc.reporters)
+			}
+		})
+	}
+}
+
+func TestLayoutPageName(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"第01版：要闻", "要闻"},
+		{"01版：要闻", "要闻"},
+		{"第 4 版: 国际", "国际"},
+		{"第12版 理论", "理论"},
+		{"要闻", "要闻"},
+		{"第01版：", ""},
+	}
+
+	for _, tc := range cases {
+		if got := layoutPageName(tc.text); got != tc.want {
+			t.Errorf("layoutPageName(%q) = %q, 期望 %q", tc.text, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package crawler
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// markdownBlockTags 按块处理的标签，其余标签按行内内容处理
+var markdownBlockTags = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "center": true,
+	"header": true, "footer": true, "figure": true, "figcaption": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "table": true, "blockquote": true, "pre": true, "hr": true,
+}
+
+// markdownSkipTags 不输出内容的标签
+var markdownSkipTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "iframe": true, "img": true,
+}
+
+// markdownRenderer 遍历html.Node树生成Markdown，每个块是一个段落、标题、列表或表格
+type markdownRenderer struct {
+	blocks []string
+	inline strings.Builder
+}
+
+// RenderMarkdown 将正文节点渲染为Markdown，保留段落、标题、加粗、斜体、列表和表格
+func RenderMarkdown(nodes []*html.Node) string {
+	r := &markdownRenderer{}
+	for _, node := range nodes {
+		r.render(node)
+	}
+	r.flush()
+	return strings.Join(r.blocks, "\n\n")
+}
+
+// render 处理一个节点：块级元素单独成块，行内内容累积到当前段落
+func (r *markdownRenderer) render(node *html.Node) {
+	if node.Type == html.TextNode {
+		r.inline.WriteString(renderInline(node))
+		return
+	}
+	if node.Type != html.ElementNode && node.Type != html.DocumentNode {
+		return
+	}
+	if markdownSkipTags[node.Data] {
+		return
+	}
+	if node.Type == html.ElementNode && !markdownBlockTags[node.Data] {
+		if node.Data == "br" {
+			r.inline.WriteString("\n")
+			return
+		}
+		if !containsBlock(node) {
+			r.inline.WriteString(renderInline(node))
+			return
+		}
+	}
+
+	switch node.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		r.flush()
+		if text := cleanMarkdownLine(renderChildrenInline(node)); text != "" {
+			r.blocks = append(r.blocks, strings.Repeat("#", int(node.Data[1]-'0'))+" "+text)
+		}
+	case "ul", "ol":
+		r.flush()
+		if list := renderList(node, 0); list != "" {
+			r.blocks = append(r.blocks, list)
+		}
+	case "table":
+		r.flush()
+		if table := renderTable(node); table != "" {
+			r.blocks = append(r.blocks, table)
+		}
+	case "blockquote":
+		r.flush()
+		if quote := renderChildrenMarkdown(node); quote != "" {
+			r.blocks = append(r.blocks, "> "+strings.ReplaceAll(quote, "\n", "\n> "))
+		}
+	case "pre":
+		r.flush()
+		if code := strings.Trim(textContent(node), "\n"); code != "" {
+			r.blocks = append(r.blocks, "```\n"+code+"\n```")
+		}
+	case "hr":
+		r.flush()
+		r.blocks = append(r.blocks, "---")
+	default:
+		// 段落和容器：前后各自成段，子节点继续处理
+		r.flush()
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			r.render(child)
+		}
+		r.flush()
+	}
+}
+
+// flush 将累积的行内内容按换行拆分为段落
+func (r *markdownRenderer) flush() {
+	text := r.inline.String()
+	r.inline.Reset()
+	for _, line := range strings.Split(text, "\n") {
+		if line = cleanMarkdownLine(line); line != "" {
+			r.blocks = append(r.blocks, escapeLineStart(line))
+		}
+	}
+}
+
+// renderInline 渲染行内节点，加粗和斜体转换为Markdown标记
+func renderInline(node *html.Node) string {
+	switch node.Type {
+	case html.TextNode:
+		return escapeMarkdown(collapseSpaces(node.Data))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch node.Data {
+	case "br":
+		return "\n"
+	case "b", "strong":
+		return wrapInline(renderChildrenInline(node), "**")
+	case "i", "em":
+		return wrapInline(renderChildrenInline(node), "*")
+	}
+	if markdownSkipTags[node.Data] {
+		return ""
+	}
+	return renderChildrenInline(node)
+}
+
+// renderChildrenInline 依次渲染子节点的行内内容
+func renderChildrenInline(node *html.Node) string {
+	var builder strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		builder.WriteString(renderInline(child))
+	}
+	return builder.String()
+}
+
+// renderChildrenMarkdown 将子节点作为独立文档渲染，用于引用和列表项
+func renderChildrenMarkdown(node *html.Node) string {
+	var children []*html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		children = append(children, child)
+	}
+	return RenderMarkdown(children)
+}
+
+// wrapInline 用标记包裹文字，标记放在首尾空白之内，空内容不输出标记
+func wrapInline(text, mark string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" || strings.Contains(trimmed, "\n") {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + mark + trimmed + mark + text[start+len(trimmed):]
+}
+
+// renderList 渲染有序或无序列表，嵌套列表每层缩进两个空格
+func renderList(list *html.Node, depth int) string {
+	var lines []string
+	index := 1
+	indent := strings.Repeat("  ", depth)
+
+	for item := list.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.Data != "li" {
+			continue
+		}
+
+		var (
+			text   strings.Builder
+			nested []string
+		)
+		for child := item.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && (child.Data == "ul" || child.Data == "ol") {
+				if sub := renderList(child, depth+1); sub != "" {
+					nested = append(nested, sub)
+				}
+				continue
+			}
+			text.WriteString(renderInline(child))
+		}
+
+		marker := "- "
+		if list.Data == "ol" {
+			marker = strconv.Itoa(index) + ". "
+		}
+		index++
+
+		line := cleanMarkdownLine(strings.ReplaceAll(text.String(), "\n", " "))
+		if line == "" && len(nested) == 0 {
+			continue
+		}
+		lines = append(lines, indent+marker+line)
+		lines = append(lines, nested...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderTable 渲染表格，第一行作为表头；只有一列的表格多用于排版，按段落输出
+func renderTable(table *html.Node) string {
+	rows := tableRows(table)
+	if len(rows) == 0 {
+		return ""
+	}
+	if len(rows[0]) == 1 {
+		var blocks []string
+		for _, row := range rows {
+			if row[0] != nil {
+				if text := renderChildrenMarkdown(row[0]); text != "" {
+					blocks = append(blocks, text)
+				}
+			}
+		}
+		return strings.Join(blocks, "\n\n")
+	}
+
+	var lines []string
+	for i, row := range rows {
+		values := make([]string, len(row))
+		for j, cell := range row {
+			if cell != nil {
+				values[j] = markdownTableCell(cell)
+			}
+		}
+		lines = append(lines, "| "+strings.Join(values, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", len(row)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownTableCell 单元格内容合并为一行，转义竖线
+func markdownTableCell(cell *html.Node) string {
+	text := renderChildrenMarkdown(cell)
+	text = strings.Join(strings.Fields(strings.ReplaceAll(text, "\n\n", " ")), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// containsBlock 判断行内元素中是否嵌套了块级元素
+func containsBlock(node *html.Node) bool {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && (markdownBlockTags[child.Data] || containsBlock(child)) {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent 节点下的全部原始文字
+func textContent(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+	var builder strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		builder.WriteString(textContent(child))
+	}
+	return builder.String()
+}
+
+// collapseSpaces 将连续的空白（含换行）合并为一个空格，全角空格保留给段首缩进处理
+func collapseSpaces(text string) string {
+	var builder strings.Builder
+	space := false
+	for _, r := range text {
+		if unicode.IsSpace(r) && r != '　' {
+			if !space {
+				builder.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		builder.WriteRune(r)
+		space = false
+	}
+	return builder.String()
+}
+
+// cleanMarkdownLine 去掉段落首尾空白和段首的全角缩进
+func cleanMarkdownLine(line string) string {
+	return strings.TrimFunc(line, unicode.IsSpace)
+}
+
+// markdownEscaper 转义正文中会被当作Markdown标记的字符
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`")
+
+// markdownLineStartRe 段首会被当作标题、引用、列表的写法
+var markdownLineStartRe = regexp.MustCompile(`^(#|>|[-+] |\d+\. )`)
+
+// escapeLineStart 段落以标题、引用或列表标记开头时转义，避免被误解析
+func escapeLineStart(line string) string {
+	if !markdownLineStartRe.MatchString(line) {
+		return line
+	}
+	if line[0] >= '0' && line[0] <= '9' {
+		dot := strings.IndexByte(line, '.')
+		return line[:dot] + `\` + line[dot:]
+	}
+	return `\` + line
+}
+
+// escapeMarkdown 转义Markdown特殊字符
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,25 +43,30 @@ func (p *Parser) ParseSearchResponse(responseBody []byte, searchURL string) (*mo
 		return p.parseHTMLResponseData(responseBody, searchURL)
 	}
 
+	// 解析署名与电头
+	for i := range response.Data.Results {
+		ApplyByline(&response.Data.Results[i])
+	}
+
 	return &response, nil
 }
 
 // parseHTMLSearchResults 解析HTML响应 - 实际上是文章页面
+// 页面中文章以外的部分带有检索结果数（如“共1234条”），作为时间段的文章总数，找不到时总数为0
 func (p *Parser) parseHTMLSearchResults(html string, searchURL string) (*models.APIResponse, error) {
-	// 直接解析这个HTML页面作为单篇文章
-	article, err := p.ParseHTMLStructure(html)
+	doc, err := htmlquery.Parse(strings.NewReader(html))
 	if err != nil {
 		log.Printf("解析文章HTML失败: %v", err)
 		// 返回空结果而不是错误，保持程序继续运行
 		return p.createEmptyResponse(), nil
 	}
 
-	var articles []models.Article
-	if article != nil {
-		article.URL = searchURL
-		log.Printf("从HTML页面解析到文章: %s", article.Title)
-		articles = append(articles, *article)
-	}
+	// 直接解析这个HTML页面作为单篇文章
+	article := p.parseDocument(doc)
+	article.URL = searchURL
+	ResolveImageURLs(article, searchURL)
+	log.Printf("从HTML页面解析到文章: %s", article.Title)
+	articles := []models.Article{*article}
 
 	response := &models.APIResponse{
 		Code:    200,
@@ -71,7 +77,7 @@ func (p *Parser) parseHTMLSearchResults(html string, searchURL string) (*models.
 			PageSize int              `json:"pageSize"`
 			Results  []models.Article `json:"results"`
 		}{
-			Total:    len(articles),
+			Total:    findResultTotal(doc),
 			PageNo:   1,
 			PageSize: len(articles),
 			Results:  articles,
@@ -80,6 +86,31 @@ func (p *Parser) parseHTMLSearchResults(html string, searchURL string) (*models.
 	return response, nil
 }
 
+// resultTotalPattern 检索结果数的文字，如“共 1,234 条”“检索到1234篇”
+var resultTotalPattern = regexp.MustCompile(`(?:共|检索到|找到)\s*(\d[\d,]*)\s*(?:条|篇)`)
+
+// findResultTotal 从文章以外的页面文字中读取检索结果数，没有时返回0
+// 正文中也可能出现“共N条”，所以先去掉文章所在的主容器
+func findResultTotal(doc *html.Node) int {
+	body := htmlquery.FindOne(doc, "//body")
+	if body == nil {
+		return 0
+	}
+	if container := htmlquery.FindOne(doc, articleContainerPath); container != nil && container.Parent != nil {
+		container.Parent.RemoveChild(container)
+	}
+
+	matches := resultTotalPattern.FindStringSubmatch(strings.Join(strings.Fields(htmlquery.InnerText(body)), ""))
+	if len(matches) < 2 {
+		return 0
+	}
+	total, err := strconv.Atoi(strings.ReplaceAll(matches[1], ",", ""))
+	if err != nil {
+		return 0
+	}
+	return total
+}
+
 // createEmptyResponse 创建空响应
 func (p *Parser) createEmptyResponse() *models.APIResponse {
 	return &models.APIResponse{
@@ -119,6 +150,9 @@ func (p *Parser) parseHTMLResponseData(htmlBody []byte, searchURL string) (*mode
 			if err := json.Unmarshal([]byte(matches[1]), &response.Data); err == nil {
 				response.Code = 200
 				response.Message = "success"
+				for i := range response.Data.Results {
+					ApplyByline(&response.Data.Results[i])
+				}
 				return &response, nil
 			}
 		}
@@ -144,34 +178,43 @@ func (p *Parser) parseHTMLResponseData(htmlBody []byte, searchURL string) (*mode
 	return response, nil
 }
 
+// articleContainerPath 文章页面的主容器，标题、特征内容和正文都是它的子div
+const articleContainerPath = "//html/body/div[1]/div[1]/div[2]/div[1]"
+
 // ParseHTMLStructure 基于xpath特征解析HTML结构 (公开方法)
 func (p *Parser) ParseHTMLStructure(htmlContent string) (*models.Article, error) {
-	article := &models.Article{}
-
 	// 解析HTML文档
 	doc, err := htmlquery.Parse(strings.NewReader(htmlContent))
 	if err != nil {
 		return nil, fmt.Errorf("解析HTML失败: %v", err)
 	}
+	return p.parseDocument(doc), nil
+}
+
+// parseDocument 从已解析的HTML文档中提取文章
+func (p *Parser) parseDocument(doc *html.Node) *models.Article {
+	article := &models.Article{}
+
+	// 页面声明的固定链接，用于生成与检索位置无关的文章ID
+	article.Permalink = findPermalink(doc)
 
 	// 基于设计文档的xpath特征进行解析
 	// /html[1]/body[1]/div[1]/div[1]/div[2]/div[1] 是主容器
 
 	// 1. 提取标题 - /html[1]/body[1]/div[1]/div[1]/div[2]/div[1]/div[1]
-	titleNode := htmlquery.FindOne(doc, "//html/body/div[1]/div[1]/div[2]/div[1]/div[1]")
+	titleNode := htmlquery.FindOne(doc, articleContainerPath+"/div[1]")
 	if titleNode != nil {
 		article.Title = strings.TrimSpace(htmlquery.InnerText(titleNode))
 	}
 
 	// 2. 查找特征内容的位置
 	// 遍历 /html[1]/body[1]/div[1]/div[1]/div[2]/div[1] 下的所有div
-	containerPath := "//html/body/div[1]/div[1]/div[2]/div[1]"
-	containerNode := htmlquery.FindOne(doc, containerPath)
+	containerNode := htmlquery.FindOne(doc, articleContainerPath)
 	if containerNode == nil {
 		log.Printf("警告: 未找到主容器div，返回空文章")
 		// 返回空文章而不是错误，避免程序崩溃
 		article.CreatedAt = time.Now()
-		return article, nil
+		return article
 	}
 
 	// 获取容器下的所有直接子div
@@ -180,7 +223,7 @@ func (p *Parser) ParseHTMLStructure(htmlContent string) (*models.Article, error)
 		log.Printf("警告: 主容器下未找到子div，返回空文章")
 		// 返回空文章而不是错误，避免程序崩溃
 		article.CreatedAt = time.Now()
-		return article, nil
+		return article
 	}
 
 	// 3. 按照设计文档的逻辑解析各字段
@@ -191,7 +234,19 @@ func (p *Parser) ParseHTMLStructure(htmlContent string) (*models.Article, error)
 		article.CreatedAt = time.Now()
 	}
 
-	return article, nil
+	return article
+}
+
+// findPermalink 读取页面中的canonical链接或og:url，没有时返回空
+func findPermalink(doc *html.Node) string {
+	for _, expr := range []string{"//link[@rel='canonical']/@href", "//meta[@property='og:url']/@content"} {
+		if node := htmlquery.FindOne(doc, expr); node != nil {
+			if href := strings.TrimSpace(htmlquery.InnerText(node)); strings.HasPrefix(href, "http") {
+				return href
+			}
+		}
+	}
+	return ""
 }
 
 // parseArticleFieldsWithXPath 使用xpath解析文章字段
@@ -239,6 +294,7 @@ func (p *Parser) parseArticleFieldsWithXPath(childDivs []*html.Node, article *mo
 			}
 		}
 		article.Content = strings.Join(contentParts, "\n\n")
+		article.ContentMarkdown = RenderMarkdown(childDivs[featureIndex+1:])
 
 	} else if featureIndex == 1 {
 		// 第二个div就是特征内容，没有subtitle
@@ -252,6 +308,7 @@ func (p *Parser) parseArticleFieldsWithXPath(childDivs []*html.Node, article *mo
 			}
 		}
 		article.Content = strings.Join(contentParts, "\n\n")
+		article.ContentMarkdown = RenderMarkdown(childDivs[featureIndex+1:])
 
 	} else {
 		// 没有找到特征内容，按默认方式处理
@@ -272,7 +329,17 @@ func (p *Parser) parseArticleFieldsWithXPath(childDivs []*html.Node, article *mo
 			}
 		}
 		article.Content = strings.Join(contentParts, "\n\n")
+		if startIndex < len(childDivs) {
+			article.ContentMarkdown = RenderMarkdown(childDivs[startIndex:])
+		}
 	}
+
+	// 4. 收集图片、图注和数据表格，标题和特征内容中不会有这些内容
+	article.Images = extractImages(childDivs[1:])
+	article.Tables = extractTables(childDivs[1:])
+
+	// 5. 从副标题和正文开头解析署名与电头
+	ApplyByline(article)
 }
 
 // isFeatureContent 判断是否为特征内容
@@ -288,40 +355,23 @@ func (p *Parser) isFeatureContent(content string) bool {
 		(strings.Contains(content, "第") && strings.Contains(content, "版"))
 }
 
+// minFeatureConfidence 特征内容解析可信度低于该值时输出警告
+const minFeatureConfidence = 0.5
+
 // parseFeatureFields 从特征内容中解析时间、版次、类型
 func (p *Parser) parseFeatureFields(raw string, article *models.Article) {
-	// 1. 解析时间 (如: 2025年8月30日)
-	timePattern := `(\d{4})年(\d{1,2})月(\d{1,2})日`
-	timeRe := regexp.MustCompile(timePattern)
-	if timeMatch := timeRe.FindStringSubmatch(raw); len(timeMatch) > 3 {
-		timeStr := fmt.Sprintf("%s年%s月%s日", timeMatch[1], timeMatch[2], timeMatch[3])
-		if publishTime, err := time.Parse("2006年1月2日", timeStr); err == nil {
-			article.PublishDate = publishTime
-		}
+	feature := ParseFeatureLine(raw)
+	if feature.Confidence < minFeatureConfidence {
+		log.Printf("警告: 特征内容解析可信度较低 (%.2f): %q", feature.Confidence, raw)
 	}
 
-	// 2. 解析版次 (如: 第1版)
-	editionPattern := `第(\d+)版`
-	editionRe := regexp.MustCompile(editionPattern)
-	if editionMatch := editionRe.FindStringSubmatch(raw); len(editionMatch) > 1 {
-		article.Edition = "第" + editionMatch[1] + "版"
+	if !feature.Date.IsZero() {
+		article.PublishDate = feature.Date
 	}
-
-	// 3. 解析类型 (如: 要闻)
-	// 查找版次后面的内容，或者】后面的非空白内容
-	typePatterns := []string{
-		`第\d+版[^】]*?([^】\s\n]+)`, // 版次后面的内容
-		`】\s*([^】\s\n【]+)`,       // 】后面的内容
+	if edition := feature.EditionName(); edition != "" {
+		article.Edition = edition
 	}
-
-	for _, pattern := range typePatterns {
-		typeRe := regexp.MustCompile(pattern)
-		if typeMatch := typeRe.FindStringSubmatch(raw); len(typeMatch) > 1 {
-			typeStr := strings.TrimSpace(typeMatch[1])
-			if typeStr != "" && !strings.Contains(typeStr, "字号") {
-				article.Type = typeStr
-				break
-			}
-		}
+	if feature.Type != "" {
+		article.Type = feature.Type
 	}
 }
@@ -0,0 +1,47 @@
+package crawler
+
+import "testing"
+
+// searchPage 构造检索结果页面，header 为文章主容器之外的页面文字，content 为正文
+func searchPage(header, content string) string {
+	return `<!DOCTYPE html><html><body><div><div>` +
+		`<div class="search-info">` + header + `</div>` +
+		`<div><div>` +
+		`<div>标题</div>` +
+		`<div>【人民日报2024年5月1日 第1版 要闻】</div>` +
+		`<div>` + content + `</div>` +
+		`</div></div>` +
+		`</div></div></body></html>`
+}
+
+func TestParseSearchResponseTotal(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		body   string
+		total  int
+	}{
+		{"共N条", "检索结果：共 1,234 条", "正文", 1234},
+		{"共N条带空白", "检索结果：共\n  1234\n 条", "正文", 1234},
+		{"数字在子元素中", "共<em>56</em>篇", "正文", 56},
+		{"检索到", "检索到 789 条结果", "正文", 789},
+		{"只在正文中出现", "", "会议出台了共12条措施", 0},
+		{"页面没有结果数", "人民日报图文数据库", "正文", 0},
+	}
+
+	parser := NewParser(nil)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			response, err := parser.ParseSearchResponse([]byte(searchPage(c.header, c.body)), "https://example.com/pd.html")
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			if len(response.Data.Results) != 1 {
+				t.Fatalf("文章数 = %d, 期望 1", len(response.Data.Results))
+			}
+			if response.Data.Total != c.total {
+				t.Errorf("总数 = %d, 期望 %d", response.Data.Total, c.total)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package crawler
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+// maxTableCaptionLength 表格前一段文字不超过这么多字时才作为表题
+const maxTableCaptionLength = 50
+
+// maxCellSpan 合并单元格最多展开的行列数，防止异常的rowspan、colspan
+const maxCellSpan = 100
+
+// spanCarry 跨行单元格在后续行中还需要占用的行数
+type spanCarry struct {
+	cell *html.Node
+	left int
+}
+
+// extractTables 收集各div中的数据表格；嵌套了其他表格或只有一列的表格多用于排版，不作为数据表格
+func extractTables(divs []*html.Node) []models.ArticleTable {
+	var tables []models.ArticleTable
+
+	for _, div := range divs {
+		for _, node := range htmlquery.Find(div, ".//table") {
+			if htmlquery.FindOne(node, ".//table") != nil {
+				continue
+			}
+			rows := tableRows(node)
+			if len(rows) < 2 || len(rows[0]) < 2 {
+				continue
+			}
+
+			table := models.ArticleTable{Caption: tableCaption(node)}
+			for i, row := range rows {
+				values := make([]string, len(row))
+				for j, cell := range row {
+					if cell != nil {
+						values[j] = cleanCaption(htmlquery.InnerText(cell))
+					}
+				}
+				if i == 0 {
+					table.Header = values
+				} else {
+					table.Rows = append(table.Rows, values)
+				}
+			}
+			tables = append(tables, table)
+		}
+	}
+
+	return tables
+}
+
+// tableRows 按行收集单元格，rowspan、colspan覆盖的位置重复原单元格，各行用nil补齐到相同列数
+func tableRows(table *html.Node) [][]*html.Node {
+	var (
+		rows    [][]*html.Node
+		pending = make(map[int]spanCarry)
+		columns int
+	)
+
+	var collect func(node *html.Node)
+	collect = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.Data {
+			case "thead", "tbody", "tfoot":
+				collect(child)
+			case "tr":
+				var row []*html.Node
+				// fill 填入上方行跨行延续下来的单元格
+				fill := func() {
+					for {
+						carry, ok := pending[len(row)]
+						if !ok {
+							return
+						}
+						row = append(row, carry.cell)
+						if carry.left--; carry.left == 0 {
+							delete(pending, len(row)-1)
+						} else {
+							pending[len(row)-1] = carry
+						}
+					}
+				}
+
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type != html.ElementNode || (cell.Data != "td" && cell.Data != "th") {
+						continue
+					}
+					fill()
+					rowspan := cellSpan(cell, "rowspan")
+					for i := 0; i < cellSpan(cell, "colspan"); i++ {
+						if rowspan > 1 {
+							pending[len(row)] = spanCarry{cell: cell, left: rowspan - 1}
+						}
+						row = append(row, cell)
+					}
+				}
+				fill()
+
+				if len(row) > 0 {
+					rows = append(rows, row)
+					if len(row) > columns {
+						columns = len(row)
+					}
+				}
+			}
+		}
+	}
+	collect(table)
+
+	for i := range rows {
+		for len(rows[i]) < columns {
+			rows[i] = append(rows[i], nil)
+		}
+	}
+	return rows
+}
+
+// cellSpan 读取rowspan或colspan，缺省或无效时为1
+func cellSpan(cell *html.Node, attr string) int {
+	span, err := strconv.Atoi(strings.TrimSpace(htmlquery.SelectAttr(cell, attr)))
+	if err != nil || span < 1 {
+		return 1
+	}
+	if span > maxCellSpan {
+		return maxCellSpan
+	}
+	return span
+}
+
+// tableCaption 表题取caption元素，没有时取表格前一个不以句号结尾的短段落
+func tableCaption(table *html.Node) string {
+	if caption := htmlquery.FindOne(table, "./caption"); caption != nil {
+		return cleanCaption(htmlquery.InnerText(caption))
+	}
+
+	for sibling := table.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+		if sibling.Type == html.TextNode && strings.TrimSpace(sibling.Data) == "" {
+			continue
+		}
+		if sibling.Type == html.ElementNode && htmlquery.FindOne(sibling, ".//table") != nil {
+			return ""
+		}
+		text := cleanCaption(htmlquery.InnerText(sibling))
+		if text == "" || utf8.RuneCountInString(text) > maxTableCaptionLength || strings.HasSuffix(text, "。") {
+			return ""
+		}
+		return text
+	}
+	return ""
+}
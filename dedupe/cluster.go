@@ -0,0 +1,136 @@
+package dedupe
+
+import (
+	"sort"
+	"time"
+)
+
+// Item 参与聚类的文章指纹
+type Item struct {
+	ID          int64
+	URL         string
+	Title       string
+	PublishDate time.Time
+	ContentHash string
+	SimHash     uint64
+}
+
+// Cluster 近似重复的文章簇
+type Cluster struct {
+	Canonical Item     `json:"canonical"`
+	Members   []Member `json:"members"` // 不含规范副本
+}
+
+// Member 簇中的重复文章
+type Member struct {
+	Item     Item `json:"item"`
+	Distance int  `json:"distance"` // 与规范副本的海明距离
+}
+
+// ClusterItems 将海明距离不超过maxDistance的文章聚为一簇（传递闭包）
+// 每簇选发布日期最早的文章作为规范副本，日期相同时选URL较短的
+func ClusterItems(items []Item, maxDistance int) []Cluster {
+	index := NewIndex(maxDistance)
+	position := make(map[int64]int, len(items))
+	parent := make([]int, len(items))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	union := func(a, b int) {
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[ra] = rb
+		}
+	}
+
+	for i, item := range items {
+		if item.ContentHash == "" {
+			continue
+		}
+		if _, exists := position[item.ID]; exists {
+			continue
+		}
+		for _, match := range index.findAll(item.ID, item.ContentHash, item.SimHash) {
+			union(i, position[match])
+		}
+		index.Add(item.ID, item.ContentHash, item.SimHash)
+		position[item.ID] = i
+	}
+
+	groups := make(map[int][]int)
+	for _, i := range position {
+		root := find(i)
+		groups[root] = append(groups[root], i)
+	}
+
+	var clusters []Cluster
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		sort.Slice(members, func(a, b int) bool {
+			return canonicalLess(items[members[a]], items[members[b]])
+		})
+
+		canonical := items[members[0]]
+		cluster := Cluster{Canonical: canonical}
+		for _, i := range members[1:] {
+			distance := Distance(canonical.SimHash, items[i].SimHash)
+			if canonical.ContentHash == items[i].ContentHash {
+				distance = 0
+			}
+			cluster.Members = append(cluster.Members, Member{Item: items[i], Distance: distance})
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(a, b int) bool {
+		return canonicalLess(clusters[a].Canonical, clusters[b].Canonical)
+	})
+	return clusters
+}
+
+// canonicalLess 规范副本的优先顺序
+func canonicalLess(a, b Item) bool {
+	if !a.PublishDate.Equal(b.PublishDate) {
+		return a.PublishDate.Before(b.PublishDate)
+	}
+	if len(a.URL) != len(b.URL) {
+		return len(a.URL) < len(b.URL)
+	}
+	return a.URL < b.URL
+}
+
+// findAll 查找所有与指纹重复的已有条目
+func (idx *Index) findAll(key int64, contentHash string, simHash uint64) []int64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	seen := make(map[int64]bool)
+	var matches []int64
+	if match, exists := idx.exact[contentHash]; exists && match != key {
+		seen[match] = true
+		matches = append(matches, match)
+	}
+	for i, b := range idx.bands {
+		value := (simHash >> b.shift) & b.mask
+		for _, candidate := range idx.buckets[i][value] {
+			if candidate.key == key || seen[candidate.key] {
+				continue
+			}
+			if Distance(simHash, candidate.simHash) <= idx.maxDistance {
+				seen[candidate.key] = true
+				matches = append(matches, candidate.key)
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,118 @@
+package dedupe
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// formatClusters 簇格式化为 "规范副本ID:成员ID/距离,..."，每簇一行
+func formatClusters(clusters []Cluster) string {
+	var lines []string
+	for _, cluster := range clusters {
+		var members []string
+		for _, member := range cluster.Members {
+			members = append(members, fmt.Sprintf("%d/%d", member.Item.ID, member.Distance))
+		}
+		lines = append(lines, fmt.Sprintf("%d:%s", cluster.Canonical.ID, strings.Join(members, ",")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestClusterItems(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name  string
+		items []Item
+		want  string
+	}{
+		{
+			name: "传递闭包",
+			items: []Item{
+				{ID: 2, PublishDate: day(2), ContentHash: "b", SimHash: flip(0, 0, 1, 2)},
+				{ID: 3, PublishDate: day(3), ContentHash: "c", SimHash: flip(0, 0, 1, 2, 3, 4, 5)}, // 与1相差6位，经2连到一簇
+				{ID: 1, PublishDate: day(1), ContentHash: "a", SimHash: 0},
+			},
+			want: "1:2/3,3/6",
+		},
+		{
+			name: "超过阈值1位不成簇",
+			items: []Item{
+				{ID: 1, PublishDate: day(1), ContentHash: "a", SimHash: 0},
+				{ID: 2, PublishDate: day(2), ContentHash: "b", SimHash: flip(0, 0, 20, 40, 60)},
+			},
+			want: "",
+		},
+		{
+			name: "内容哈希相同距离为0",
+			items: []Item{
+				{ID: 1, PublishDate: day(1), ContentHash: "a", SimHash: 0},
+				{ID: 2, PublishDate: day(1), ContentHash: "a", SimHash: ^uint64(0)},
+			},
+			want: "1:2/0",
+		},
+		{
+			name: "日期相同选URL较短的",
+			items: []Item{
+				{ID: 1, URL: "https://example.com/long/1.html", PublishDate: day(1), ContentHash: "a", SimHash: 0},
+				{ID: 2, URL: "https://example.com/2.html", PublishDate: day(1), ContentHash: "b", SimHash: flip(0, 7)},
+			},
+			want: "2:1/1",
+		},
+		{
+			name: "跳过没有内容哈希和重复ID的条目",
+			items: []Item{
+				{ID: 1, PublishDate: day(1), ContentHash: "a", SimHash: 0},
+				{ID: 1, PublishDate: day(1), ContentHash: "a", SimHash: 0},
+				{ID: 2, PublishDate: day(2), SimHash: 0},
+			},
+			want: "",
+		},
+		{
+			name: "多个簇按规范副本排序",
+			items: []Item{
+				{ID: 3, PublishDate: day(3), ContentHash: "c", SimHash: ^uint64(0)},
+				{ID: 4, PublishDate: day(4), ContentHash: "d", SimHash: flip(^uint64(0), 63)},
+				{ID: 1, PublishDate: day(1), ContentHash: "a", SimHash: 0},
+				{ID: 2, PublishDate: day(2), ContentHash: "b", SimHash: flip(0, 0, 1)},
+			},
+			want: "1:2/2\n3:4/1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := formatClusters(ClusterItems(tc.items, 3)); got != tc.want {
+				t.Errorf("ClusterItems =\n%s\n期望\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClusterTexts(t *testing.T) {
+	texts := []string{meetingText, meetingEdited, weatherText}
+	items := make([]Item, len(texts))
+	for i, text := range texts {
+		items[i] = Item{ID: int64(i + 1), PublishDate: time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC),
+			ContentHash: ContentHash(text), SimHash: SimHash(text)}
+	}
+
+	// 阈值等于两篇近似正文的距离时成簇，小1时不成簇；无关正文始终不在簇中
+	distance := Distance(items[0].SimHash, items[1].SimHash)
+	cases := []struct {
+		maxDistance int
+		want        string
+	}{
+		{distance, fmt.Sprintf("1:2/%d", distance)},
+		{distance - 1, ""},
+	}
+	for _, tc := range cases {
+		if got := formatClusters(ClusterItems(items, tc.maxDistance)); got != tc.want {
+			t.Errorf("ClusterItems(阈值%d) = %q, 期望 %q", tc.maxDistance, got, tc.want)
+		}
+	}
+}
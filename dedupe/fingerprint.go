@@ -0,0 +1,81 @@
+package dedupe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// Fingerprint 为文章计算内容哈希和SimHash，已有指纹或正文为空时不处理
+func Fingerprint(article *models.Article) {
+	if article.ContentHash != "" || strings.TrimSpace(article.Content) == "" {
+		return
+	}
+	article.ContentHash = ContentHash(article.Content)
+	article.SimHash = SimHash(article.Content)
+}
+
+// ContentHash 计算正文的精确哈希，忽略空白和标点差异
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(normalize(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// SimHash 计算正文的64位SimHash，特征为相邻两个字符组成的片段
+func SimHash(content string) uint64 {
+	runes := []rune(normalize(content))
+	if len(runes) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	addFeature := func(feature string) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		value := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if value&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	if len(runes) == 1 {
+		addFeature(string(runes))
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		addFeature(string(runes[i : i+2]))
+	}
+
+	var fingerprint uint64
+	for i := 0; i < 64; i++ {
+		if weights[i] > 0 {
+			fingerprint |= 1 << uint(i)
+		}
+	}
+	return fingerprint
+}
+
+// Distance 计算两个SimHash的海明距离
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// normalize 去掉空白和标点，只保留文字和数字，英文转小写
+func normalize(content string) string {
+	var builder strings.Builder
+	builder.Grow(len(content))
+	for _, r := range content {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return builder.String()
+}
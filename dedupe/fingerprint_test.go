@@ -0,0 +1,66 @@
+package dedupe
+
+import (
+	"testing"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+const (
+	// meetingText 与 meetingEdited 只差"指出"和"强调"两个字
+	meetingText = "国务院总理主持召开国务院常务会议，听取关于今年以来经济运行情况的汇报，部署进一步扩大内需、促进消费的政策措施，" +
+		"研究推进城市更新和老旧小区改造等工作。会议指出，今年以来各地区各部门认真贯彻落实党中央决策部署，经济运行总体平稳、稳中有进。"
+	meetingEdited = "国务院总理主持召开国务院常务会议，听取关于今年以来经济运行情况的汇报，部署进一步扩大内需、促进消费的政策措施，" +
+		"研究推进城市更新和老旧小区改造等工作。会议强调，今年以来各地区各部门认真贯彻落实党中央决策部署，经济运行总体平稳、稳中有进。"
+	weatherText = "明天晴转多云，北风三到四级，最高气温二十五度，最低气温十五度，请注意添加衣物，出行注意安全，预计周末有小雨。"
+)
+
+func TestContentHash(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{"忽略空白和标点", "经济运行总体平稳，稳中有进。", "经济运行 总体平稳\n稳中有进", true},
+		{"英文不区分大小写", "GDP增长5%", "gdp增长5", true},
+		{"文字不同", "会议指出", "会议强调", false},
+	}
+
+	for _, tc := range cases {
+		if same := ContentHash(tc.a) == ContentHash(tc.b); same != tc.same {
+			t.Errorf("%s: ContentHash(%q) 与 ContentHash(%q) 相同 = %v, 期望 %v", tc.name, tc.a, tc.b, same, tc.same)
+		}
+	}
+}
+
+func TestSimHashDistance(t *testing.T) {
+	if d := Distance(SimHash(meetingText), SimHash("  "+meetingText+"！")); d != 0 {
+		t.Errorf("只差空白和标点的正文距离 = %d, 期望 0", d)
+	}
+	near := Distance(SimHash(meetingText), SimHash(meetingEdited))
+	far := Distance(SimHash(meetingText), SimHash(weatherText))
+	if near == 0 || near > 6 || far <= 20 {
+		t.Errorf("近似正文距离 = %d, 无关正文距离 = %d, 期望前者在1到6之间、后者大于20", near, far)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	article := &models.Article{Content: meetingText}
+	Fingerprint(article)
+	if article.ContentHash != ContentHash(meetingText) || article.SimHash != SimHash(meetingText) {
+		t.Errorf("指纹 = %s %x, 期望 %s %x", article.ContentHash, article.SimHash, ContentHash(meetingText), SimHash(meetingText))
+	}
+
+	// 已有指纹的文章不重新计算
+	stored := &models.Article{Content: meetingText, ContentHash: "stored", SimHash: 1}
+	Fingerprint(stored)
+	if stored.ContentHash != "stored" || stored.SimHash != 1 {
+		t.Errorf("已有指纹被覆盖: %s %x", stored.ContentHash, stored.SimHash)
+	}
+
+	empty := &models.Article{Content: " \n"}
+	Fingerprint(empty)
+	if empty.ContentHash != "" || empty.SimHash != 0 {
+		t.Errorf("空正文的指纹 = %s %x, 期望为空", empty.ContentHash, empty.SimHash)
+	}
+}
@@ -0,0 +1,106 @@
+package dedupe
+
+import "sync"
+
+// Index 指纹索引，支持精确哈希查找和基于分段的SimHash近似查找
+// 将64位指纹分为 maxDistance+1 段，海明距离不超过maxDistance的两个指纹至少有一段完全相同
+type Index struct {
+	mu          sync.RWMutex
+	maxDistance int
+	bands       []band
+	exact       map[string]int64
+	buckets     []map[uint64][]entry
+}
+
+// band 指纹中的一段
+type band struct {
+	shift uint
+	mask  uint64
+}
+
+// entry 索引项
+type entry struct {
+	key     int64
+	simHash uint64
+}
+
+// NewIndex 创建指纹索引
+func NewIndex(maxDistance int) *Index {
+	if maxDistance < 0 {
+		maxDistance = 0
+	}
+	if maxDistance > 15 {
+		maxDistance = 15
+	}
+
+	count := maxDistance + 1
+	idx := &Index{
+		maxDistance: maxDistance,
+		exact:       make(map[string]int64),
+		buckets:     make([]map[uint64][]entry, count),
+	}
+
+	offset := uint(0)
+	for i := 0; i < count; i++ {
+		width := uint(64 / count)
+		if i < 64%count {
+			width++
+		}
+		idx.bands = append(idx.bands, band{shift: offset, mask: (1 << width) - 1})
+		idx.buckets[i] = make(map[uint64][]entry)
+		offset += width
+	}
+
+	return idx
+}
+
+// Add 添加指纹，key为文章的规范ID
+func (idx *Index) Add(key int64, contentHash string, simHash uint64) {
+	if contentHash == "" {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if _, exists := idx.exact[contentHash]; !exists {
+		idx.exact[contentHash] = key
+	}
+	for i, b := range idx.bands {
+		value := (simHash >> b.shift) & b.mask
+		idx.buckets[i][value] = append(idx.buckets[i][value], entry{key: key, simHash: simHash})
+	}
+}
+
+// Find 查找与指纹重复的已有条目，忽略key相同的条目
+// 返回匹配的key和海明距离，精确哈希匹配时距离为0
+func (idx *Index) Find(key int64, contentHash string, simHash uint64) (int64, int, bool) {
+	if contentHash == "" {
+		return 0, 0, false
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if match, exists := idx.exact[contentHash]; exists && match != key {
+		return match, 0, true
+	}
+
+	bestKey, bestDistance, found := int64(0), idx.maxDistance+1, false
+	for i, b := range idx.bands {
+		value := (simHash >> b.shift) & b.mask
+		for _, candidate := range idx.buckets[i][value] {
+			if candidate.key == key {
+				continue
+			}
+			if distance := Distance(simHash, candidate.simHash); distance < bestDistance {
+				bestKey, bestDistance, found = candidate.key, distance, true
+			}
+		}
+	}
+
+	if !found {
+		return 0, 0, false
+	}
+	return bestKey, bestDistance, true
+}
@@ -0,0 +1,85 @@
+package dedupe
+
+import "testing"
+
+// flip 翻转指纹中的指定位
+func flip(simHash uint64, positions ...uint) uint64 {
+	for _, position := range positions {
+		simHash ^= 1 << position
+	}
+	return simHash
+}
+
+func TestIndexFind(t *testing.T) {
+	const base = uint64(0x0123456789abcdef)
+
+	// maxDistance为3时64位分为4段，每段16位
+	cases := []struct {
+		name        string
+		contentHash string
+		simHash     uint64
+		key         int64
+		wantKey     int64
+		wantDist    int
+		wantFound   bool
+	}{
+		{"精确哈希相同", "a", flip(base, 0, 20, 40, 60), 2, 1, 0, true},
+		{"同一段内3位不同", "b", flip(base, 0, 1, 2), 2, 1, 3, true},
+		{"分散在3段中", "b", flip(base, 0, 20, 40), 2, 1, 3, true},
+		{"超过阈值1位", "b", flip(base, 0, 20, 40, 60), 2, 0, 0, false},
+		{"同一段内超过阈值1位", "b", flip(base, 0, 1, 2, 3), 2, 0, 0, false},
+		{"忽略相同key", "a", base, 1, 0, 0, false},
+	}
+
+	index := NewIndex(3)
+	index.Add(1, "a", base)
+	index.Add(9, "", base) // 没有内容哈希的不加入索引
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, distance, found := index.Find(tc.key, tc.contentHash, tc.simHash)
+			if key != tc.wantKey || distance != tc.wantDist || found != tc.wantFound {
+				t.Errorf("Find = %d, %d, %v, 期望 %d, %d, %v", key, distance, found, tc.wantKey, tc.wantDist, tc.wantFound)
+			}
+		})
+	}
+}
+
+func TestIndexFindNearest(t *testing.T) {
+	index := NewIndex(3)
+	index.Add(1, "a", flip(0, 0, 1, 2))
+	index.Add(2, "b", flip(0, 0))
+	index.Add(3, "c", flip(0, 0, 1))
+
+	if key, distance, found := index.Find(4, "d", 0); key != 2 || distance != 1 || !found {
+		t.Errorf("Find = %d, %d, %v, 期望最近的 2, 1, true", key, distance, found)
+	}
+}
+
+func TestNewIndexBands(t *testing.T) {
+	cases := []struct {
+		maxDistance int
+		bands       int
+	}{
+		{-1, 1},
+		{0, 1},
+		{3, 4},
+		{6, 7},
+		{20, 16},
+	}
+
+	for _, tc := range cases {
+		index := NewIndex(tc.maxDistance)
+		width := uint64(0)
+		for _, b := range index.bands {
+			if b.shift != uint(width) {
+				t.Errorf("NewIndex(%d) 分段不连续: 偏移 %d, 期望 %d", tc.maxDistance, b.shift, width)
+			}
+			for mask := b.mask; mask != 0; mask >>= 1 {
+				width++
+			}
+		}
+		if len(index.bands) != tc.bands || width != 64 {
+			t.Errorf("NewIndex(%d) 分为 %d 段共 %d 位, 期望 %d 段共 64 位", tc.maxDistance, len(index.bands), width, tc.bands)
+		}
+	}
+}
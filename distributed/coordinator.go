@@ -0,0 +1,426 @@
+package distributed
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/utils"
+)
+
+// ErrLeaseLost 租约已过期并被回收或任务已重新分配，worker的结果不再被接受
+var ErrLeaseLost = errors.New("租约已失效")
+
+// Coordinator 持有抓取任务计划，通过HTTP向worker发放有时限的租约
+// 租约到期未续约的任务重新排队，分配次数达到上限的任务标记为失败
+// 每次状态变化都写入状态文件，重启后继续未完成的任务
+type Coordinator struct {
+	mu            sync.Mutex
+	tasks         []*models.Task
+	byID          map[string]*models.Task
+	stats         models.CrawlerStats
+	leaseDuration time.Duration
+	maxAttempts   int
+	statePath     string
+	mux           *http.ServeMux
+	now           func() time.Time // 当前时间，测试时替换
+}
+
+// coordinatorState 状态文件内容
+type coordinatorState struct {
+	Tasks []*models.Task      `json:"tasks"`
+	Stats models.CrawlerStats `json:"stats"`
+}
+
+// NewCoordinator 创建coordinator
+func NewCoordinator(cfg config.DistributedConfig) *Coordinator {
+	c := &Coordinator{
+		byID:          make(map[string]*models.Task),
+		leaseDuration: cfg.LeaseDuration,
+		maxAttempts:   cfg.MaxAttempts,
+		statePath:     cfg.StateFile,
+		mux:           http.NewServeMux(),
+		now:           time.Now,
+	}
+	if c.leaseDuration <= 0 {
+		c.leaseDuration = 5 * time.Minute
+	}
+	if c.maxAttempts <= 0 {
+		c.maxAttempts = 1
+	}
+
+	c.mux.HandleFunc(pathLease, c.handleLease)
+	c.mux.HandleFunc(pathHeartbeat, c.handleHeartbeat)
+	c.mux.HandleFunc(pathComplete, c.handleComplete)
+	c.mux.HandleFunc(pathStatus, c.handleStatus)
+	return c
+}
+
+// Handler 返回HTTP处理器
+func (c *Coordinator) Handler() http.Handler {
+	return c.mux
+}
+
+// Load 从状态文件恢复任务，返回是否存在状态文件
+// 上次退出时仍在进行的任务无法确认结果，重新排队
+func (c *Coordinator) Load() (bool, error) {
+	data, err := os.ReadFile(c.statePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("读取任务状态失败: %v", err)
+	}
+
+	var state coordinatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false, fmt.Errorf("解析任务状态失败: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tasks = state.Tasks
+	c.stats = state.Stats
+	c.byID = make(map[string]*models.Task)
+	for _, task := range c.tasks {
+		if task.Status == models.TaskStatusRunning {
+			c.releaseTask(task)
+		}
+		c.byID[task.ID] = task
+	}
+	return true, nil
+}
+
+// Plan 按日期范围生成任务，替换已有的任务和统计
+func (c *Coordinator) Plan(dateRanges []utils.DateRange) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.tasks = nil
+	c.byID = make(map[string]*models.Task)
+	c.stats = models.CrawlerStats{}
+	for _, dateRange := range dateRanges {
+		id := dateRange.Key()
+		if _, exists := c.byID[id]; exists {
+			continue
+		}
+		task := &models.Task{
+			ID:        id,
+			StartDate: dateRange.Start,
+			EndDate:   dateRange.End,
+			Status:    models.TaskStatusPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		c.tasks = append(c.tasks, task)
+		c.byID[id] = task
+	}
+	return c.save()
+}
+
+// Status 返回任务进度，withTasks为true时包含全部任务
+func (c *Coordinator) Status(withTasks bool) *Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reclaimExpired(c.now())
+	return c.status(withTasks)
+}
+
+// Lease 为worker分配下一个待抓取的任务，没有可分配的任务时Task为nil
+func (c *Coordinator) Lease(workerID string) (*LeaseResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.reclaimExpired(now)
+
+	for _, task := range c.tasks {
+		if task.Status != models.TaskStatusPending {
+			continue
+		}
+		leaseID, err := newLeaseID()
+		if err != nil {
+			return nil, err
+		}
+		task.Status = models.TaskStatusRunning
+		task.WorkerID = workerID
+		task.LeaseID = leaseID
+		task.LeaseExpiresAt = now.Add(c.leaseDuration)
+		task.UpdatedAt = now
+		if err := c.save(); err != nil {
+			return nil, err
+		}
+
+		log.Printf("分配任务 %s 给 %s (第%d次)", task.ID, workerID, task.RetryCount+1)
+		leased := *task
+		return &LeaseResponse{
+			Task:      &leased,
+			LeaseID:   leaseID,
+			ExpiresAt: task.LeaseExpiresAt,
+			Seconds:   int(c.leaseDuration / time.Second),
+		}, nil
+	}
+
+	return &LeaseResponse{Done: c.status(false).Finished()}, nil
+}
+
+// Heartbeat 延长租约
+func (c *Coordinator) Heartbeat(req *HeartbeatRequest) (*HeartbeatResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.reclaimExpired(now)
+
+	task, err := c.leasedTask(req.TaskID, req.WorkerID, req.LeaseID)
+	if err != nil {
+		return nil, err
+	}
+	task.LeaseExpiresAt = now.Add(c.leaseDuration)
+	task.UpdatedAt = now
+	return &HeartbeatResponse{ExpiresAt: task.LeaseExpiresAt}, nil
+}
+
+// Complete 记录任务结果：成功时累加统计，失败时重新排队或在分配次数达到上限后标记为失败
+func (c *Coordinator) Complete(req *CompleteRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reclaimExpired(c.now())
+
+	task, err := c.leasedTask(req.TaskID, req.WorkerID, req.LeaseID)
+	if err != nil {
+		return err
+	}
+
+	if req.Error != "" {
+		log.Printf("任务 %s 失败 (%s): %s", task.ID, req.WorkerID, req.Error)
+		task.LastError = req.Error
+		c.releaseTask(task)
+	} else {
+		log.Printf("任务 %s 完成 (%s): %d 篇文章", task.ID, req.WorkerID, req.Stats.TotalArticles)
+		task.Status = models.TaskStatusCompleted
+		task.Articles = req.Stats.TotalArticles
+		task.LastError = ""
+		task.LeaseID = ""
+		task.LeaseExpiresAt = time.Time{}
+		task.UpdatedAt = c.now()
+		c.stats.Merge(&req.Stats)
+	}
+	return c.save()
+}
+
+// leasedTask 查找持有指定租约的任务
+func (c *Coordinator) leasedTask(taskID, workerID, leaseID string) (*models.Task, error) {
+	task, ok := c.byID[taskID]
+	if !ok {
+		return nil, fmt.Errorf("任务不存在: %s", taskID)
+	}
+	if task.Status != models.TaskStatusRunning || task.LeaseID != leaseID || task.WorkerID != workerID {
+		return nil, ErrLeaseLost
+	}
+	return task, nil
+}
+
+// reclaimExpired 回收到期未续约的任务
+func (c *Coordinator) reclaimExpired(now time.Time) {
+	changed := false
+	for _, task := range c.tasks {
+		if task.Status == models.TaskStatusRunning && now.After(task.LeaseExpiresAt) {
+			log.Printf("任务 %s 的租约已过期 (%s)，重新分配", task.ID, task.WorkerID)
+			task.LastError = fmt.Sprintf("租约过期 (%s)", task.WorkerID)
+			c.releaseTask(task)
+			changed = true
+		}
+	}
+	if changed {
+		if err := c.save(); err != nil {
+			log.Printf("保存任务状态失败: %v", err)
+		}
+	}
+}
+
+// releaseTask 结束一次分配：计入分配次数，未达到上限时重新排队
+func (c *Coordinator) releaseTask(task *models.Task) {
+	task.RetryCount++
+	task.Status = models.TaskStatusPending
+	if task.RetryCount >= c.maxAttempts {
+		task.Status = models.TaskStatusFailed
+	}
+	task.LeaseID = ""
+	task.LeaseExpiresAt = time.Time{}
+	task.UpdatedAt = c.now()
+}
+
+// status 统计各状态的任务数
+func (c *Coordinator) status(withTasks bool) *Status {
+	status := &Status{Total: len(c.tasks), Stats: c.stats}
+	// 响应在锁外编码，报告总数需要复制
+	status.Stats.ReportedTotals = make(map[string]int, len(c.stats.ReportedTotals))
+	for key, total := range c.stats.ReportedTotals {
+		status.Stats.ReportedTotals[key] = total
+	}
+	for _, task := range c.tasks {
+		switch task.Status {
+		case models.TaskStatusPending:
+			status.Pending++
+		case models.TaskStatusRunning:
+			status.Running++
+		case models.TaskStatusCompleted:
+			status.Completed++
+		case models.TaskStatusFailed:
+			status.Failed++
+		}
+	}
+	status.Stats.CompletedTasks = status.Completed
+	status.Stats.FailedTasks = status.Failed
+	status.Stats.TotalTasks = status.Total
+
+	if withTasks {
+		for _, task := range c.tasks {
+			copied := *task
+			status.Tasks = append(status.Tasks, &copied)
+		}
+	}
+	return status
+}
+
+// save 写入状态文件，先写临时文件再重命名
+func (c *Coordinator) save() error {
+	if c.statePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(coordinatorState{Tasks: c.tasks, Stats: c.stats}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化任务状态失败: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.statePath), 0755); err != nil {
+		return fmt.Errorf("创建状态目录失败: %v", err)
+	}
+	tmpPath := c.statePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("写入任务状态失败: %v", err)
+	}
+	if err := os.Rename(tmpPath, c.statePath); err != nil {
+		return fmt.Errorf("保存任务状态失败: %v", err)
+	}
+	return nil
+}
+
+// handleLease 领取任务
+// POST /api/lease {"worker_id": "..."}
+func (c *Coordinator) handleLease(w http.ResponseWriter, r *http.Request) {
+	var req LeaseRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.WorkerID == "" {
+		writeError(w, http.StatusBadRequest, "缺少worker_id")
+		return
+	}
+
+	resp, err := c.Lease(req.WorkerID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleHeartbeat 续约
+// POST /api/heartbeat {"worker_id": "...", "task_id": "...", "lease_id": "..."}
+func (c *Coordinator) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	var req HeartbeatRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	resp, err := c.Heartbeat(&req)
+	if err != nil {
+		writeError(w, errorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleComplete 汇报任务结果
+// POST /api/complete {"worker_id": "...", "task_id": "...", "lease_id": "...", "stats": {...}, "error": ""}
+func (c *Coordinator) handleComplete(w http.ResponseWriter, r *http.Request) {
+	var req CompleteRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := c.Complete(&req); err != nil {
+		writeError(w, errorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleStatus 任务进度
+// GET /api/status?tasks=1
+func (c *Coordinator) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "只支持GET请求")
+		return
+	}
+	writeJSON(w, http.StatusOK, c.Status(r.URL.Query().Get("tasks") != ""))
+}
+
+// decodeRequest 解析POST请求体，失败时写入错误响应并返回false
+func decodeRequest(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "只支持POST请求")
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("解析请求失败: %v", err))
+		return false
+	}
+	return true
+}
+
+// errorStatus 租约失效返回409，其他错误返回400
+func errorStatus(err error) int {
+	if errors.Is(err, ErrLeaseLost) {
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+// newLeaseID 生成随机租约ID
+func newLeaseID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成租约ID失败: %v", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// writeJSON 写入JSON响应
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("写入响应失败: %v", err)
+	}
+}
+
+// writeError 写入错误响应
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
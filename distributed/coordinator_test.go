@@ -0,0 +1,252 @@
+package distributed
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/utils"
+)
+
+const testLease = 5 * time.Minute
+
+// fakeClock 可以手动推进的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.now = f.now.Add(d)
+}
+
+// newTestCoordinator 创建使用假时钟和临时状态文件的coordinator，计划为从2024-01-01开始的days个单日任务
+func newTestCoordinator(t *testing.T, statePath string, maxAttempts, days int) (*Coordinator, *fakeClock) {
+	t.Helper()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)}
+	c := NewCoordinator(config.DistributedConfig{
+		LeaseDuration: testLease,
+		MaxAttempts:   maxAttempts,
+		StateFile:     statePath,
+	})
+	c.now = clock.Now
+
+	var dateRanges []utils.DateRange
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < days; i++ {
+		day := start.AddDate(0, 0, i)
+		dateRanges = append(dateRanges, utils.DateRange{Start: day, End: day})
+	}
+	if err := c.Plan(dateRanges); err != nil {
+		t.Fatalf("生成任务失败: %v", err)
+	}
+	return c, clock
+}
+
+// mustLease 领取任务，没有任务时失败
+func mustLease(t *testing.T, c *Coordinator, workerID string) *LeaseResponse {
+	t.Helper()
+
+	resp, err := c.Lease(workerID)
+	if err != nil {
+		t.Fatalf("领取任务失败: %v", err)
+	}
+	if resp.Task == nil {
+		t.Fatalf("%s 没有领到任务", workerID)
+	}
+	return resp
+}
+
+// taskState 返回任务的当前状态和分配次数
+func taskState(c *Coordinator, id string) (string, int) {
+	for _, task := range c.Status(true).Tasks {
+		if task.ID == id {
+			return task.Status, task.RetryCount
+		}
+	}
+	return "", -1
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	cases := []struct {
+		name        string
+		maxAttempts int
+		steps       []time.Duration // 依次推进时钟，两步之间发送心跳
+		wantStatus  string
+		wantRetry   int
+	}{
+		{"租约未到期", 3, []time.Duration{4 * time.Minute}, models.TaskStatusRunning, 0},
+		{"心跳续约", 3, []time.Duration{4 * time.Minute, 4 * time.Minute}, models.TaskStatusRunning, 0},
+		{"到期重新排队", 3, []time.Duration{6 * time.Minute}, models.TaskStatusPending, 1},
+		{"续约后仍到期", 3, []time.Duration{4 * time.Minute, 6 * time.Minute}, models.TaskStatusPending, 1},
+		{"到期达到分配上限", 1, []time.Duration{6 * time.Minute}, models.TaskStatusFailed, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, clock := newTestCoordinator(t, "", tc.maxAttempts, 1)
+			lease := mustLease(t, c, "w1")
+			heartbeat := &HeartbeatRequest{WorkerID: "w1", TaskID: lease.Task.ID, LeaseID: lease.LeaseID}
+
+			for i, step := range tc.steps {
+				if i > 0 {
+					if _, err := c.Heartbeat(heartbeat); err != nil {
+						t.Fatalf("续约失败: %v", err)
+					}
+				}
+				clock.Advance(step)
+			}
+
+			status, retry := taskState(c, lease.Task.ID)
+			if status != tc.wantStatus || retry != tc.wantRetry {
+				t.Errorf("任务状态 = %s (分配%d次), 期望 %s (分配%d次)", status, retry, tc.wantStatus, tc.wantRetry)
+			}
+
+			// 租约被回收后，原worker的心跳和结果都不再被接受
+			if tc.wantStatus != models.TaskStatusRunning {
+				if _, err := c.Heartbeat(heartbeat); !errors.Is(err, ErrLeaseLost) {
+					t.Errorf("过期租约续约返回 %v, 期望 ErrLeaseLost", err)
+				}
+				err := c.Complete(&CompleteRequest{WorkerID: "w1", TaskID: lease.Task.ID, LeaseID: lease.LeaseID})
+				if !errors.Is(err, ErrLeaseLost) {
+					t.Errorf("过期租约汇报返回 %v, 期望 ErrLeaseLost", err)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaseReassignment(t *testing.T) {
+	c, clock := newTestCoordinator(t, "", 3, 1)
+
+	first := mustLease(t, c, "w1")
+	if resp, err := c.Lease("w2"); err != nil || resp.Task != nil || resp.Done {
+		t.Fatalf("任务已分配时 w2 领取结果 = %+v, %v, 期望暂时没有任务", resp, err)
+	}
+
+	clock.Advance(testLease + time.Second)
+	second := mustLease(t, c, "w2")
+	if second.Task.ID != first.Task.ID {
+		t.Fatalf("重新分配的任务 = %s, 期望 %s", second.Task.ID, first.Task.ID)
+	}
+	if second.LeaseID == first.LeaseID {
+		t.Fatalf("重新分配后租约ID没有变化")
+	}
+	if second.Task.RetryCount != 1 {
+		t.Errorf("重新分配的任务分配次数 = %d, 期望 1", second.Task.RetryCount)
+	}
+
+	// 超时的worker迟到的结果不计入统计
+	late := &CompleteRequest{WorkerID: "w1", TaskID: first.Task.ID, LeaseID: first.LeaseID,
+		Stats: models.CrawlerStats{TotalArticles: 7}}
+	if err := c.Complete(late); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("原worker汇报返回 %v, 期望 ErrLeaseLost", err)
+	}
+
+	done := &CompleteRequest{WorkerID: "w2", TaskID: second.Task.ID, LeaseID: second.LeaseID,
+		Stats: models.CrawlerStats{TotalArticles: 5}}
+	if err := c.Complete(done); err != nil {
+		t.Fatalf("新worker汇报失败: %v", err)
+	}
+
+	status := c.Status(false)
+	if status.Completed != 1 || status.Stats.TotalArticles != 5 {
+		t.Errorf("完成 %d 个任务、%d 篇文章, 期望 1 个任务、5 篇文章", status.Completed, status.Stats.TotalArticles)
+	}
+	if resp, err := c.Lease("w1"); err != nil || resp.Task != nil || !resp.Done {
+		t.Errorf("全部任务完成后领取结果 = %+v, %v, 期望Done", resp, err)
+	}
+}
+
+func TestMaxAttempts(t *testing.T) {
+	cases := []struct {
+		name        string
+		maxAttempts int
+		failures    int
+		wantStatus  string
+	}{
+		{"失败后重新排队", 3, 1, models.TaskStatusPending},
+		{"失败次数未达上限", 3, 2, models.TaskStatusPending},
+		{"失败次数达到上限", 3, 3, models.TaskStatusFailed},
+		{"只分配一次", 1, 1, models.TaskStatusFailed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newTestCoordinator(t, "", tc.maxAttempts, 1)
+			for i := 0; i < tc.failures; i++ {
+				lease := mustLease(t, c, "w1")
+				err := c.Complete(&CompleteRequest{WorkerID: "w1", TaskID: lease.Task.ID, LeaseID: lease.LeaseID, Error: "请求失败"})
+				if err != nil {
+					t.Fatalf("汇报失败: %v", err)
+				}
+			}
+
+			status, retry := taskState(c, "2024-01-01~2024-01-01")
+			if status != tc.wantStatus || retry != tc.failures {
+				t.Errorf("任务状态 = %s (分配%d次), 期望 %s (分配%d次)", status, retry, tc.wantStatus, tc.failures)
+			}
+			resp, err := c.Lease("w1")
+			if err != nil {
+				t.Fatalf("领取任务失败: %v", err)
+			}
+			// 仍在排队的任务可以再次领取，失败的任务不再分配
+			requeued := tc.wantStatus == models.TaskStatusPending
+			if (resp.Task != nil) != requeued || resp.Done == requeued {
+				t.Errorf("领取结果 Task=%v Done=%v 与任务状态 %s 不符", resp.Task != nil, resp.Done, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRestartRecovery(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "coordinator.json")
+	c, _ := newTestCoordinator(t, statePath, 3, 2)
+
+	done := mustLease(t, c, "w1")
+	if err := c.Complete(&CompleteRequest{WorkerID: "w1", TaskID: done.Task.ID, LeaseID: done.LeaseID,
+		Stats: models.CrawlerStats{TotalArticles: 3}}); err != nil {
+		t.Fatalf("汇报失败: %v", err)
+	}
+	running := mustLease(t, c, "w1")
+
+	// 重启：新的coordinator从状态文件恢复
+	restarted := NewCoordinator(config.DistributedConfig{LeaseDuration: testLease, MaxAttempts: 3, StateFile: statePath})
+	found, err := restarted.Load()
+	if err != nil || !found {
+		t.Fatalf("恢复任务状态 = %v, %v, 期望存在状态文件", found, err)
+	}
+
+	cases := []struct {
+		id         string
+		wantStatus string
+		wantRetry  int
+	}{
+		{done.Task.ID, models.TaskStatusCompleted, 0},
+		{running.Task.ID, models.TaskStatusPending, 1}, // 重启前进行中的任务无法确认结果，重新排队
+	}
+	for _, tc := range cases {
+		if status, retry := taskState(restarted, tc.id); status != tc.wantStatus || retry != tc.wantRetry {
+			t.Errorf("任务 %s 状态 = %s (分配%d次), 期望 %s (分配%d次)", tc.id, status, retry, tc.wantStatus, tc.wantRetry)
+		}
+	}
+	if got := restarted.Status(false).Stats.TotalArticles; got != 3 {
+		t.Errorf("恢复后文章数 = %d, 期望 3", got)
+	}
+
+	// 重启前的租约不再有效，任务重新分配
+	err = restarted.Complete(&CompleteRequest{WorkerID: "w1", TaskID: running.Task.ID, LeaseID: running.LeaseID})
+	if !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("重启前的租约汇报返回 %v, 期望 ErrLeaseLost", err)
+	}
+	if lease := mustLease(t, restarted, "w2"); lease.Task.ID != running.Task.ID {
+		t.Errorf("重启后分配的任务 = %s, 期望 %s", lease.Task.ID, running.Task.ID)
+	}
+}
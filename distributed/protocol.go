@@ -0,0 +1,71 @@
+package distributed
+
+import (
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// coordinator的HTTP接口，请求和响应都是JSON
+const (
+	pathLease     = "/api/lease"     // POST 领取任务
+	pathHeartbeat = "/api/heartbeat" // POST 续约
+	pathComplete  = "/api/complete"  // POST 汇报结果
+	pathStatus    = "/api/status"    // GET 任务进度
+)
+
+// LeaseRequest 领取任务请求
+type LeaseRequest struct {
+	WorkerID string `json:"worker_id"`
+}
+
+// LeaseResponse 领取任务响应：Task为nil且Done为false表示暂时没有可分配的任务，稍后再试
+type LeaseResponse struct {
+	Task      *models.Task `json:"task,omitempty"`
+	LeaseID   string       `json:"lease_id,omitempty"`
+	ExpiresAt time.Time    `json:"expires_at,omitempty"`
+	Seconds   int          `json:"lease_seconds,omitempty"` // 租约时长，worker据此确定续约间隔，不依赖两台机器的时钟一致
+	Done      bool         `json:"done"`                    // 全部任务已结束，worker可以退出
+}
+
+// HeartbeatRequest 续约请求
+type HeartbeatRequest struct {
+	WorkerID string `json:"worker_id"`
+	TaskID   string `json:"task_id"`
+	LeaseID  string `json:"lease_id"`
+}
+
+// HeartbeatResponse 续约响应
+type HeartbeatResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CompleteRequest 汇报任务结果，Error不为空表示任务失败
+type CompleteRequest struct {
+	WorkerID string              `json:"worker_id"`
+	TaskID   string              `json:"task_id"`
+	LeaseID  string              `json:"lease_id"`
+	Stats    models.CrawlerStats `json:"stats"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// Status 任务进度
+type Status struct {
+	Total     int                 `json:"total"`
+	Pending   int                 `json:"pending"`
+	Running   int                 `json:"running"`
+	Completed int                 `json:"completed"`
+	Failed    int                 `json:"failed"`
+	Stats     models.CrawlerStats `json:"stats"` // 已完成任务的文章统计
+	Tasks     []*models.Task      `json:"tasks,omitempty"`
+}
+
+// Finished 全部任务已完成或失败
+func (s *Status) Finished() bool {
+	return s.Pending == 0 && s.Running == 0
+}
+
+// errorResponse 错误响应
+type errorResponse struct {
+	Error string `json:"error"`
+}
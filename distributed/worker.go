@@ -0,0 +1,242 @@
+package distributed
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// Client coordinator的HTTP客户端
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient 创建coordinator客户端
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Lease 领取任务
+func (c *Client) Lease(workerID string) (*LeaseResponse, error) {
+	var resp LeaseResponse
+	if err := c.post(pathLease, LeaseRequest{WorkerID: workerID}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Heartbeat 续约，租约已失效时返回ErrLeaseLost
+func (c *Client) Heartbeat(req *HeartbeatRequest) (*HeartbeatResponse, error) {
+	var resp HeartbeatResponse
+	if err := c.post(pathHeartbeat, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Complete 汇报任务结果
+func (c *Client) Complete(req *CompleteRequest) error {
+	return c.post(pathComplete, req, nil)
+}
+
+// Status 获取任务进度
+func (c *Client) Status(withTasks bool) (*Status, error) {
+	url := c.baseURL + pathStatus
+	if withTasks {
+		url += "?tasks=1"
+	}
+	resp, err := c.http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("请求coordinator失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var status Status
+	if err := decodeResponse(resp, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// post 发送JSON请求并解析响应，out为nil时忽略响应内容
+func (c *Client) post(path string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("序列化请求失败: %v", err)
+	}
+	resp, err := c.http.Post(c.baseURL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("请求coordinator失败: %v", err)
+	}
+	defer resp.Body.Close()
+	return decodeResponse(resp, out)
+}
+
+// decodeResponse 检查状态码并解析响应，409表示租约失效
+func decodeResponse(resp *http.Response, out interface{}) error {
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		if resp.StatusCode == http.StatusConflict {
+			return ErrLeaseLost
+		}
+		return fmt.Errorf("coordinator返回错误 (%d): %s", resp.StatusCode, errResp.Error)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("解析coordinator响应失败: %v", err)
+	}
+	return nil
+}
+
+// maxCompleteAttempts 汇报任务结果的最多尝试次数
+const maxCompleteAttempts = 3
+
+// CrawlFunc 抓取一个任务，返回该任务的统计
+type CrawlFunc func(task *models.Task) (*models.CrawlerStats, error)
+
+// Worker 从coordinator领取任务并抓取，抓取期间定期续约，结束后汇报结果
+type Worker struct {
+	id           string
+	client       *Client
+	pollInterval time.Duration
+}
+
+// NewWorker 创建worker，pollInterval为暂时没有可分配任务或coordinator不可用时的等待时间
+func NewWorker(id string, client *Client, pollInterval time.Duration) *Worker {
+	if pollInterval <= 0 {
+		pollInterval = 10 * time.Second
+	}
+	return &Worker{id: id, client: client, pollInterval: pollInterval}
+}
+
+// Run 循环领取并抓取任务，直到coordinator报告全部任务结束或stop被关闭
+// coordinator暂时不可用时等待后重试；stop被关闭时crawl应尽快返回，正在抓取的任务不再汇报
+func (w *Worker) Run(crawl CrawlFunc, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		lease, err := w.client.Lease(w.id)
+		if err != nil {
+			log.Printf("领取任务失败，%v后重试: %v", w.pollInterval, err)
+			if !w.wait(stop) {
+				return
+			}
+			continue
+		}
+		if lease.Done {
+			fmt.Println("全部任务已结束")
+			return
+		}
+		if lease.Task == nil {
+			if !w.wait(stop) {
+				return
+			}
+			continue
+		}
+
+		w.runTask(crawl, lease, stop)
+	}
+}
+
+// runTask 抓取一个任务并汇报结果，抓取期间按租约时长的三分之一续约
+// 抓取期间stop被关闭时任务没有抓完，不汇报结果，租约到期后由其他worker重新抓取
+func (w *Worker) runTask(crawl CrawlFunc, lease *LeaseResponse, stop <-chan struct{}) {
+	task := lease.Task
+	fmt.Printf("领取任务 %s (租约 %d 秒)\n", task.ID, lease.Seconds)
+
+	interval := time.Duration(lease.Seconds) * time.Second / 3
+	if interval < time.Second {
+		interval = time.Second
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		w.heartbeat(task.ID, lease.LeaseID, interval, done)
+		close(stopped)
+	}()
+
+	stats, err := crawl(task)
+	close(done)
+	<-stopped
+
+	select {
+	case <-stop:
+		log.Printf("任务 %s 被中断，不汇报结果，租约到期后重新分配", task.ID)
+		return
+	default:
+	}
+
+	req := &CompleteRequest{WorkerID: w.id, TaskID: task.ID, LeaseID: lease.LeaseID}
+	if stats != nil {
+		req.Stats = *stats
+	}
+	if err != nil {
+		req.Error = err.Error()
+	}
+
+	// coordinator暂时不可用时重试几次，仍然失败的任务在租约过期后重新分配
+	for attempt := 1; attempt <= maxCompleteAttempts; attempt++ {
+		err := w.client.Complete(req)
+		if err == nil {
+			return
+		}
+		if errors.Is(err, ErrLeaseLost) {
+			log.Printf("任务 %s 的租约已失效，结果未被接受（已保存的文章不受影响）", task.ID)
+			return
+		}
+		log.Printf("汇报任务 %s 失败 (第%d次): %v", task.ID, attempt, err)
+		if attempt < maxCompleteAttempts {
+			time.Sleep(w.pollInterval)
+		}
+	}
+}
+
+// heartbeat 定期续约直到done被关闭，租约失效后停止续约
+func (w *Worker) heartbeat(taskID, leaseID string, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			_, err := w.client.Heartbeat(&HeartbeatRequest{WorkerID: w.id, TaskID: taskID, LeaseID: leaseID})
+			if errors.Is(err, ErrLeaseLost) {
+				log.Printf("任务 %s 的租约已失效，停止续约", taskID)
+				return
+			}
+			if err != nil {
+				log.Printf("续约失败: %v", err)
+			}
+		}
+	}
+}
+
+// wait 等待一个轮询间隔，stop被关闭时返回false
+func (w *Worker) wait(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return false
+	case <-time.After(w.pollInterval):
+		return true
+	}
+}
@@ -4,16 +4,32 @@ import "time"
 
 // Article 文章数据模型
 type Article struct {
-	ID          int       `json:"id" db:"id" csv:"id"`                         // id
-	URL         string    `json:"url" db:"url" csv:"url"`                     // url 原始链接 也就是请求的链接
-	Title       string    `json:"title" db:"title" csv:"title"`               // title 标题
-	Subtitle    string    `json:"subtitle" db:"subtitle" csv:"subtitle"`       // subtitle 记者名字/小标题，可能是空的
-	Raw         string    `json:"raw" db:"raw" csv:"raw"`                     // raw 特征的内容的全部
-	PublishDate time.Time `json:"publish_date" db:"publish_date" csv:"publish_date"` // time 来自特征的内容的时间，如 2025年8月30日
-	Edition     string    `json:"edition" db:"edition" csv:"edition"`         // edition 第几版，如第1版
-	Type        string    `json:"type" db:"type" csv:"type"`                  // type 类型如要闻，可能是空的
-	Content     string    `json:"content" db:"content" csv:"content"`         // content 文章内容
-	CreatedAt   time.Time `json:"created_at" db:"created_at" csv:"created_at"` // 创建时间（系统字段）
+	ID               int64          `json:"id" db:"id" csv:"id"`                                              // id 规范ID，站点文档ID或日期、版次、标题的哈希，见CanonicalID
+	URL              string         `json:"url" db:"url" csv:"url"`                                           // url 原始链接 也就是请求的链接
+	Permalink        string         `json:"permalink" db:"permalink" csv:"permalink"`                         // 文章页面声明的固定链接，可能是空的
+	Title            string         `json:"title" db:"title" csv:"title"`                                     // title 标题
+	Subtitle         string         `json:"subtitle" db:"subtitle" csv:"subtitle"`                            // subtitle 记者名字/小标题，可能是空的
+	Raw              string         `json:"raw" db:"raw" csv:"raw"`                                           // raw 特征的内容的全部
+	PublishDate      time.Time      `json:"publish_date" db:"publish_date" csv:"publish_date"`                // time 来自特征的内容的时间，如 2025年8月30日
+	Edition          string         `json:"edition" db:"edition" csv:"edition"`                               // edition 第几版，如第1版
+	Type             string         `json:"type" db:"type" csv:"type"`                                        // type 类型如要闻，可能是空的
+	Content          string         `json:"content" db:"content" csv:"content"`                               // content 文章内容
+	ContentMarkdown  string         `json:"content_markdown" db:"content_markdown" csv:"content_markdown"`    // 正文的Markdown形式，保留段落、标题、强调、列表和表格
+	Tables           []ArticleTable `json:"tables,omitempty" db:"-" csv:"tables"`                             // 表格，MySQL存储在article_tables和article_table_cells表
+	Images           []ArticleImage `json:"images,omitempty" db:"-" csv:"images"`                             // 图片和图注，MySQL存储在article_images表
+	Reporters        []string       `json:"reporters" db:"reporters" csv:"reporters"`                         // 记者，存储时以逗号连接
+	Agency           string         `json:"agency" db:"agency" csv:"agency"`                                  // 发稿机构，如本报、新华社
+	DatelineLocation string         `json:"dateline_location" db:"dateline_location" csv:"dateline_location"` // 电头地点，如北京
+	DatelineDate     time.Time      `json:"dateline_date" db:"dateline_date" csv:"dateline_date"`             // 电头日期，如本报北京8月29日电
+	Column           string         `json:"column" db:"column_name" csv:"column"`                             // 栏目，如人民时评、今日谈，可能是空的
+	Ordinal          int            `json:"ordinal" db:"ordinal" csv:"ordinal"`                               // 文章在版面中的顺序，从1开始，按版面抓取时才有
+	PageCoords       string         `json:"page_coords" db:"page_coords" csv:"page_coords"`                   // 文章在版面图上的热区坐标，如 "x1,y1,x2,y2"
+	Summary          string         `json:"summary" db:"summary" csv:"summary"`                               // 摘要
+	Keywords         string         `json:"keywords" db:"keywords" csv:"keywords"`                            // 关键词，逗号分隔
+	ContentHash      string         `json:"content_hash" db:"content_hash" csv:"content_hash"`                // 正文精确哈希
+	SimHash          uint64         `json:"simhash" db:"simhash" csv:"simhash"`                               // 正文SimHash，用于近似重复检测
+	DuplicateOf      int64          `json:"duplicate_of,omitempty" db:"duplicate_of" csv:"-"`                 // 重复文章的规范副本ID，0表示不是重复文章
+	CreatedAt        time.Time      `json:"created_at" db:"created_at" csv:"created_at"`                      // 创建时间（系统字段）
 }
 
 // SearchQuery 搜索查询参数
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// documentIDRe 站点文章地址中的文档ID，如电子版的 content_30090001.html
+var documentIDRe = regexp.MustCompile(`content_(\d{1,18})\.html?`)
+
+// hashedIDFlag 由发布日期、版次和标题哈希得到的ID都设置该位，不会与站点文档ID重复
+const hashedIDFlag = int64(1) << 62
+
+// DocumentID 从文章地址中提取站点的文档ID，没有时返回0
+func DocumentID(url string) int64 {
+	match := documentIDRe.FindStringSubmatch(url)
+	if match == nil {
+		return 0
+	}
+	id, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil || id >= hashedIDFlag {
+		return 0
+	}
+	return id
+}
+
+// CanonicalID 计算文章的规范ID：优先使用固定链接或URL中的站点文档ID，
+// 没有时使用发布日期、版次和标题的哈希，与检索时的分页位置无关
+//
+// 哈希ID的局限：检索方式下没有站点文档ID时，标题是唯一能区分同版文章的稳定字段，
+// 因此网站修改标题后文章会得到新的ID，作为新文章保存而不是旧文章的修订；
+// 哈希使用规范化之后的标题（与存储的标题一致，可由已存储的数据重新计算），
+// 修改 normalization 配置中影响标题的选项后，受影响标题的哈希ID也会变化
+func CanonicalID(article *Article) int64 {
+	for _, url := range []string{article.Permalink, article.URL} {
+		if id := DocumentID(url); id > 0 {
+			return id
+		}
+	}
+
+	key := strings.Join(strings.Fields(article.Title), "")
+	if key == "" {
+		// 没有标题时只能用URL区分
+		key = article.URL
+	} else {
+		date := ""
+		if !article.PublishDate.IsZero() {
+			date = article.PublishDate.Format("2006-01-02")
+		}
+		key = date + "|" + article.Edition + "|" + key
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return int64(binary.BigEndian.Uint64(sum[:8]))&(hashedIDFlag-1) | hashedIDFlag
+}
+
+// EnsureID 文章没有ID时填入规范ID，站点接口返回的ID保持不变
+func (a *Article) EnsureID() {
+	if a.ID == 0 {
+		a.ID = CanonicalID(a)
+	}
+}
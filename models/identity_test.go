@@ -0,0 +1,107 @@
+package models
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDocumentID(t *testing.T) {
+	cases := []struct {
+		url  string
+		want int64
+	}{
+		{"http://paper.people.com.cn/rmrb/pc/content/202408/30/content_30090001.html", 30090001},
+		{"http://paper.people.com.cn/rmrb/html/2014-08/30/content_1234.htm", 1234},
+		{"https://data.people.com.cn/rmrb/pd.html?pageNo=1&position=3", 0},
+		{"http://example.com/content_4611686018427387904.html", 0}, // 超出站点文档ID的范围
+		{"", 0},
+	}
+
+	for _, tc := range cases {
+		if got := DocumentID(tc.url); got != tc.want {
+			t.Errorf("DocumentID(%q) = %d, 期望 %d", tc.url, got, tc.want)
+		}
+	}
+}
+
+func TestCanonicalID(t *testing.T) {
+	day := time.Date(2024, 8, 30, 0, 0, 0, 0, time.UTC)
+	searchURL := func(position int) string {
+		return "https://data.people.com.cn/rmrb/pd.html?pageNo=1&position=" + strconv.Itoa(position)
+	}
+	base := Article{URL: searchURL(0), Title: "坚定不移推进改革", PublishDate: day, Edition: "第1版"}
+
+	cases := []struct {
+		name string
+		a, b Article
+		same bool
+	}{
+		{"检索位置不同", base,
+			Article{URL: searchURL(5), Title: "坚定不移推进改革", PublishDate: day, Edition: "第1版"}, true},
+		{"标题空白不同", base,
+			Article{URL: searchURL(1), Title: " 坚定不移 推进改革\n", PublishDate: day, Edition: "第1版"}, true},
+		{"标题不同", base,
+			Article{URL: searchURL(0), Title: "坚定不移深化改革", PublishDate: day, Edition: "第1版"}, false},
+		{"版次不同", base,
+			Article{URL: searchURL(0), Title: "坚定不移推进改革", PublishDate: day, Edition: "第2版"}, false},
+		{"日期不同", base,
+			Article{URL: searchURL(0), Title: "坚定不移推进改革", PublishDate: day.AddDate(0, 0, 1), Edition: "第1版"}, false},
+		{"没有标题时按URL区分", Article{URL: searchURL(0), PublishDate: day}, Article{URL: searchURL(1), PublishDate: day}, false},
+		{"固定链接相同", Article{URL: searchURL(0), Title: "旧标题", Permalink: "http://paper.people.com.cn/rmrb/pc/content/202408/30/content_30090001.html"},
+			Article{URL: searchURL(7), Title: "新标题", Permalink: "http://paper.people.com.cn/rmrb/pc/content/202408/30/content_30090001.html"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := CanonicalID(&tc.a), CanonicalID(&tc.b)
+			if (a == b) != tc.same {
+				t.Errorf("CanonicalID = %d 和 %d, 期望相同 %v", a, b, tc.same)
+			}
+		})
+	}
+}
+
+func TestCanonicalIDSource(t *testing.T) {
+	permalink := "http://paper.people.com.cn/rmrb/pc/content/202408/30/content_30090001.html"
+	cases := []struct {
+		name    string
+		article Article
+		want    int64 // 0表示应为哈希ID
+	}{
+		{"固定链接中的文档ID", Article{Permalink: permalink, URL: "https://data.people.com.cn/rmrb/pd.html"}, 30090001},
+		{"URL中的文档ID", Article{URL: "http://paper.people.com.cn/rmrb/pc/content/202408/30/content_42.html"}, 42},
+		{"固定链接优先于URL", Article{Permalink: permalink, URL: "http://paper.people.com.cn/content_42.html"}, 30090001},
+		{"没有文档ID", Article{URL: "https://data.people.com.cn/rmrb/pd.html", Title: "标题"}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CanonicalID(&tc.article)
+			if tc.want != 0 {
+				if got != tc.want {
+					t.Errorf("CanonicalID = %d, 期望 %d", got, tc.want)
+				}
+				return
+			}
+			// 哈希ID为正数且设置了标志位，不会与站点文档ID重复
+			if got <= 0 || got&hashedIDFlag == 0 {
+				t.Errorf("CanonicalID = %d, 期望设置了标志位的正数哈希ID", got)
+			}
+		})
+	}
+}
+
+func TestEnsureID(t *testing.T) {
+	article := &Article{ID: 7, URL: "http://paper.people.com.cn/content_42.html"}
+	article.EnsureID()
+	if article.ID != 7 {
+		t.Errorf("已有ID被替换为 %d", article.ID)
+	}
+
+	article.ID = 0
+	article.EnsureID()
+	if article.ID != 42 {
+		t.Errorf("EnsureID 填入 %d, 期望 42", article.ID)
+	}
+}
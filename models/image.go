@@ -0,0 +1,10 @@
+package models
+
+// ArticleImage 文章中的图片及其图注
+type ArticleImage struct {
+	URL       string `json:"url"`                  // 图片地址
+	Caption   string `json:"caption,omitempty"`    // 图注，可能是空的
+	LocalPath string `json:"local_path,omitempty"` // 下载到本地的路径，未下载时为空
+	SHA256    string `json:"sha256,omitempty"`     // 图片内容哈希，也是本地文件名
+	Size      int64  `json:"size,omitempty"`       // 图片字节数
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Issue 某一天出版的一期报纸
+type Issue struct {
+	Date          time.Time `json:"date"`           // 出版日期
+	TotalEditions int       `json:"total_editions"` // 当天的版数
+	Pages         []Page    `json:"pages"`          // 按版次排列的版面
+	CrawledAt     time.Time `json:"crawled_at"`     // 抓取时间
+}
+
+// Page 一期报纸中的一个版面
+type Page struct {
+	Edition  int           `json:"edition"`            // 版次，从1开始
+	Name     string        `json:"name"`               // 版面名称，如要闻、评论
+	URL      string        `json:"url"`                // 版面地址
+	PDFURL   string        `json:"pdf_url,omitempty"`  // 版面PDF地址，可能是空的
+	Articles []PageArticle `json:"articles,omitempty"` // 版面中的文章，按在版面中的顺序排列
+}
+
+// PageArticle 版面中的一篇文章，通过URL关联到文章
+type PageArticle struct {
+	Ordinal int    `json:"ordinal"`          // 在版面中的顺序，从1开始
+	URL     string `json:"url"`              // 文章地址
+	Title   string `json:"title,omitempty"`  // 版面中列出的标题
+	Coords  string `json:"coords,omitempty"` // 版面图热区坐标，没有热区时为空
+}
+
+// EditionName 版次名称，如 第1版，与Article.Edition格式一致
+func (p Page) EditionName() string {
+	if p.Edition <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("第%d版", p.Edition)
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// CrawlPlan 补抓计划，可通过 crawl --plan 直接执行
+type CrawlPlan struct {
+	CreatedAt time.Time    `json:"created_at"`
+	Windows   []PlanWindow `json:"windows"`
+}
+
+// PlanWindow 补抓时间段
+type PlanWindow struct {
+	Start  string `json:"start"`  // 开始日期 YYYY-MM-DD
+	End    string `json:"end"`    // 结束日期 YYYY-MM-DD
+	Reason string `json:"reason"` // 需要补抓的原因
+}
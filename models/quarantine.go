@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// QuarantinedArticle 未通过校验而被隔离的文章
+type QuarantinedArticle struct {
+	Article       Article   `json:"article"`
+	Reasons       []string  `json:"reasons"`        // 未通过的校验规则
+	WindowStart   string    `json:"window_start"`   // 抓取时间段开始日期 YYYY-MM-DD
+	WindowEnd     string    `json:"window_end"`     // 抓取时间段结束日期 YYYY-MM-DD
+	QuarantinedAt time.Time `json:"quarantined_at"` // 隔离时间
+}
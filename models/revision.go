@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// Revision 文章的历史版本，重新抓取时标题或正文发生变化，旧版本归档为一条修订记录
+type Revision struct {
+	Article    Article   `json:"article"`     // 被替换前的文章内容
+	ArchivedAt time.Time `json:"archived_at"` // 被新版本替换的时间
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// CrawlRun 单次抓取运行记录
+type CrawlRun struct {
+	ID         string       `json:"id"`
+	ConfigHash string       `json:"config_hash"` // 配置指纹
+	RangeStart string       `json:"range_start"` // 抓取范围开始日期 YYYY-MM-DD
+	RangeEnd   string       `json:"range_end"`   // 抓取范围结束日期 YYYY-MM-DD
+	Status     string       `json:"status"`      // completed, interrupted
+	StartTime  time.Time    `json:"start_time"`
+	EndTime    time.Time    `json:"end_time"`
+	Stats      CrawlerStats `json:"stats"`
+}
+
+// RunStatus 运行状态常量
+const (
+	RunStatusCompleted   = "completed"
+	RunStatusInterrupted = "interrupted"
+)
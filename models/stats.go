@@ -0,0 +1,19 @@
+package models
+
+// CorpusStats 已存储文章的语料统计
+type CorpusStats struct {
+	TotalArticles    int         `json:"total_articles"`
+	AvgContentLength float64     `json:"avg_content_length"` // 平均正文长度（字符数）
+	EarliestDate     string      `json:"earliest_date"`
+	LatestDate       string      `json:"latest_date"`
+	ByYear           []StatCount `json:"by_year"`
+	ByMonth          []StatCount `json:"by_month"`
+	ByEdition        []StatCount `json:"by_edition"`
+	ByType           []StatCount `json:"by_type"`
+}
+
+// StatCount 分组计数
+type StatCount struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
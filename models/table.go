@@ -0,0 +1,8 @@
+package models
+
+// ArticleTable 文章中的表格，表头和各行的单元格已展开合并单元格
+type ArticleTable struct {
+	Caption string     `json:"caption,omitempty"` // 表题，可能是空的
+	Header  []string   `json:"header"`            // 表头
+	Rows    [][]string `json:"rows"`              // 数据行，每行与表头列数相同
+}
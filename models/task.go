@@ -13,6 +13,13 @@ type Task struct {
 	RetryCount int       `json:"retry_count"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	// 分布式抓取时由coordinator维护的租约信息
+	WorkerID       string    `json:"worker_id,omitempty"`        // 持有租约的worker
+	LeaseID        string    `json:"lease_id,omitempty"`         // 当前租约，汇报结果时必须一致
+	LeaseExpiresAt time.Time `json:"lease_expires_at,omitempty"` // 租约到期时间，到期未续约的任务重新分配
+	LastError      string    `json:"last_error,omitempty"`       // 最近一次失败的原因
+	Articles       int       `json:"articles,omitempty"`         // 完成时保存的文章数
 }
 
 // TaskStatus 任务状态常量
@@ -25,11 +32,27 @@ const (
 
 // CrawlerStats 爬虫统计信息
 type CrawlerStats struct {
-	TotalTasks     int           `json:"total_tasks"`
-	CompletedTasks int           `json:"completed_tasks"`
-	FailedTasks    int           `json:"failed_tasks"`
-	TotalArticles  int           `json:"total_articles"`
-	StartTime      time.Time     `json:"start_time"`
-	Duration       time.Duration `json:"duration"`
-	ArticlesPerSec float64       `json:"articles_per_sec"`
+	TotalTasks     int            `json:"total_tasks"`
+	CompletedTasks int            `json:"completed_tasks"`
+	FailedTasks    int            `json:"failed_tasks"`
+	TotalArticles  int            `json:"total_articles"`
+	Quarantined    int            `json:"quarantined"` // 未通过校验被隔离的文章数
+	Skipped        int            `json:"skipped"`     // 已存储而跳过的文章数
+	StartTime      time.Time      `json:"start_time"`
+	Duration       time.Duration  `json:"duration"`
+	ArticlesPerSec float64        `json:"articles_per_sec"`
+	ReportedTotals map[string]int `json:"reported_totals,omitempty"` // 网站报告的各时间段文章总数，键为 YYYY-MM-DD~YYYY-MM-DD
+}
+
+// Merge 累加另一份统计的文章数和网站报告的总数，任务数和时间由调用方维护
+func (s *CrawlerStats) Merge(other *CrawlerStats) {
+	s.TotalArticles += other.TotalArticles
+	s.Quarantined += other.Quarantined
+	s.Skipped += other.Skipped
+	if len(other.ReportedTotals) > 0 && s.ReportedTotals == nil {
+		s.ReportedTotals = make(map[string]int)
+	}
+	for key, total := range other.ReportedTotals {
+		s.ReportedTotals[key] = total
+	}
 }
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// SearchWindow search方式抓取完的时间段及其中文章的规范ID，重新抓取时用于判断时间段是否已全部存储
+type SearchWindow struct {
+	Key       string    `json:"key"`        // 时间段，YYYY-MM-DD~YYYY-MM-DD
+	Total     int       `json:"total"`      // 抓取时网站报告的文章总数
+	IDs       []int64   `json:"ids"`        // 检索结果中文章的规范ID
+	CrawledAt time.Time `json:"crawled_at"` // 记录时间
+}
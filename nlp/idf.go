@@ -0,0 +1,93 @@
+package nlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// IDF 逆文档频率统计，从已存储的文章构建
+type IDF struct {
+	Docs int            `json:"docs"` // 文档总数
+	DF   map[string]int `json:"df"`   // 每个词出现的文档数
+}
+
+// NewIDF 创建空的IDF统计
+func NewIDF() *IDF {
+	return &IDF{DF: make(map[string]int)}
+}
+
+// LoadIDF 读取IDF统计文件，文件不存在时返回空统计（此时关键词只按词频排序）
+func LoadIDF(path string) (*IDF, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewIDF(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取IDF文件失败: %v", err)
+	}
+
+	idf := NewIDF()
+	if err := json.Unmarshal(data, idf); err != nil {
+		return nil, fmt.Errorf("解析IDF文件失败: %v", err)
+	}
+	if idf.DF == nil {
+		idf.DF = make(map[string]int)
+	}
+	return idf, nil
+}
+
+// AddDocument 将一篇文档的词语计入统计，同一文档中重复的词只计一次
+func (idf *IDF) AddDocument(words []string) {
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		idf.DF[word]++
+	}
+	idf.Docs++
+}
+
+// Prune 去掉文档数少于minDF的词，减小统计文件体积
+func (idf *IDF) Prune(minDF int) {
+	for word, df := range idf.DF {
+		if df < minDF {
+			delete(idf.DF, word)
+		}
+	}
+}
+
+// Value 返回词语的IDF值，没有统计数据时返回1
+// 被剪枝或从未出现的词按只出现在一篇文档中计算
+func (idf *IDF) Value(word string) float64 {
+	if idf.Docs == 0 {
+		return 1
+	}
+	df := idf.DF[word]
+	if df == 0 {
+		df = 1
+	}
+	return math.Log(float64(idf.Docs+1)/float64(df+1)) + 1
+}
+
+// Save 保存IDF统计（先写临时文件再替换）
+func (idf *IDF) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建IDF目录失败: %v", err)
+	}
+
+	data, err := json.Marshal(idf)
+	if err != nil {
+		return fmt.Errorf("序列化IDF失败: %v", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("写入IDF文件失败: %v", err)
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,194 @@
+package nlp
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed data/stopwords.txt
+var stopwordsData string
+
+// 关键词提取方法
+const (
+	MethodTFIDF    = "tfidf"
+	MethodTextRank = "textrank"
+)
+
+// textRankWindow TextRank共现窗口大小
+const textRankWindow = 5
+
+// Keyword 关键词及其权重
+type Keyword struct {
+	Word   string  `json:"word"`
+	Weight float64 `json:"weight"`
+}
+
+// Extractor 关键词提取器
+type Extractor struct {
+	segmenter *Segmenter
+	idf       *IDF
+	stopwords map[string]bool
+}
+
+// NewExtractor 创建关键词提取器，idf为nil时TF-IDF退化为按词频排序
+func NewExtractor(segmenter *Segmenter, idf *IDF) *Extractor {
+	if idf == nil {
+		idf = NewIDF()
+	}
+
+	stopwords := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(stopwordsData))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			stopwords[line] = true
+		}
+	}
+
+	return &Extractor{segmenter: segmenter, idf: idf, stopwords: stopwords}
+}
+
+// Extract 按指定方法提取前topK个关键词
+func (e *Extractor) Extract(text, method string, topK int) ([]Keyword, error) {
+	switch method {
+	case MethodTFIDF, "":
+		return e.TFIDF(text, topK), nil
+	case MethodTextRank:
+		return e.TextRank(text, topK), nil
+	default:
+		return nil, fmt.Errorf("不支持的关键词提取方法: %s", method)
+	}
+}
+
+// Candidates 分词并过滤出可作为关键词的词语，保持原文顺序
+func (e *Extractor) Candidates(text string) []string {
+	var words []string
+	for _, word := range e.segmenter.Cut(text) {
+		if e.isCandidate(word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// isCandidate 至少两个字、不是停用词、不是纯数字
+func (e *Extractor) isCandidate(word string) bool {
+	if utf8.RuneCountInString(word) < 2 || e.stopwords[word] {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// TFIDF 按词频乘以逆文档频率排序
+func (e *Extractor) TFIDF(text string, topK int) []Keyword {
+	words := e.Candidates(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	tf := make(map[string]float64)
+	for _, word := range words {
+		tf[word]++
+	}
+
+	weights := make(map[string]float64, len(tf))
+	for word, count := range tf {
+		weights[word] = count / float64(len(words)) * e.idf.Value(word)
+	}
+	return topKeywords(weights, topK)
+}
+
+// TextRank 以窗口内共现关系构建词图，按PageRank得分排序
+func (e *Extractor) TextRank(text string, topK int) []Keyword {
+	words := e.Candidates(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	graph := make(map[string]map[string]float64)
+	for i, word := range words {
+		if graph[word] == nil {
+			graph[word] = make(map[string]float64)
+		}
+		for j := i + 1; j < len(words) && j < i+textRankWindow; j++ {
+			if words[j] == word {
+				continue
+			}
+			if graph[words[j]] == nil {
+				graph[words[j]] = make(map[string]float64)
+			}
+			graph[word][words[j]]++
+			graph[words[j]][word]++
+		}
+	}
+
+	return topKeywords(pageRank(graph), topK)
+}
+
+// pageRank 在带权无向图上迭代计算节点得分
+func pageRank(graph map[string]map[string]float64) map[string]float64 {
+	const (
+		damping    = 0.85
+		iterations = 30
+	)
+
+	outWeight := make(map[string]float64, len(graph))
+	scores := make(map[string]float64, len(graph))
+	for node, edges := range graph {
+		scores[node] = 1
+		for _, weight := range edges {
+			outWeight[node] += weight
+		}
+	}
+
+	for iter := 0; iter < iterations; iter++ {
+		updated := make(map[string]float64, len(graph))
+		for node, edges := range graph {
+			sum := 0.0
+			for neighbor, weight := range edges {
+				sum += weight / outWeight[neighbor] * scores[neighbor]
+			}
+			updated[node] = (1 - damping) + damping*sum
+		}
+		scores = updated
+	}
+	return scores
+}
+
+// topKeywords 按权重降序取前topK个，权重相同时按词语排序保证结果稳定
+func topKeywords(weights map[string]float64, topK int) []Keyword {
+	keywords := make([]Keyword, 0, len(weights))
+	for word, weight := range weights {
+		keywords = append(keywords, Keyword{Word: word, Weight: weight})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Weight != keywords[j].Weight {
+			return keywords[i].Weight > keywords[j].Weight
+		}
+		return keywords[i].Word < keywords[j].Word
+	})
+
+	if topK > 0 && len(keywords) > topK {
+		keywords = keywords[:topK]
+	}
+	return keywords
+}
+
+// JoinKeywords 将关键词用逗号连接，用于存储
+func JoinKeywords(keywords []Keyword) string {
+	words := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		words[i] = keyword.Word
+	}
+	return strings.Join(words, ",")
+}
@@ -0,0 +1,188 @@
+package nlp
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed data/dict.txt
+var dictData string
+
+var (
+	defaultSegmenter     *Segmenter
+	defaultSegmenterOnce sync.Once
+)
+
+// unknownFreq 词典之外的单字词频
+const unknownFreq = 1
+
+// Segmenter 基于词典的中文分词器
+// 对连续汉字构建所有可能的切分，取各词词频概率乘积最大的切分（最大概率路径）
+type Segmenter struct {
+	freq   map[string]float64
+	total  float64
+	maxLen int // 词典中最长词的字数
+}
+
+// DefaultSegmenter 返回使用内置词典的分词器（只加载一次）
+func DefaultSegmenter() *Segmenter {
+	defaultSegmenterOnce.Do(func() {
+		defaultSegmenter = &Segmenter{freq: make(map[string]float64)}
+		defaultSegmenter.loadDict(strings.NewReader(dictData))
+	})
+	return defaultSegmenter
+}
+
+// NewSegmenter 创建分词器，userDict不为空时在内置词典基础上加载用户词典
+func NewSegmenter(userDict string) (*Segmenter, error) {
+	if userDict == "" {
+		return DefaultSegmenter(), nil
+	}
+
+	s := &Segmenter{freq: make(map[string]float64)}
+	s.loadDict(strings.NewReader(dictData))
+
+	file, err := os.Open(userDict)
+	if err != nil {
+		return nil, fmt.Errorf("打开用户词典失败: %v", err)
+	}
+	defer file.Close()
+	s.loadDict(file)
+
+	return s, nil
+}
+
+// loadDict 读取词典，每行"词语 词频"，词频省略时使用默认值
+func (s *Segmenter) loadDict(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		freq := 1000.0
+		if len(fields) > 1 {
+			if value, err := strconv.ParseFloat(fields[1], 64); err == nil && value > 0 {
+				freq = value
+			}
+		}
+		s.AddWord(fields[0], freq)
+	}
+}
+
+// AddWord 向词典添加词语
+func (s *Segmenter) AddWord(word string, freq float64) {
+	s.total += freq - s.freq[word]
+	s.freq[word] = freq
+	if length := utf8.RuneCountInString(word); length > s.maxLen {
+		s.maxLen = length
+	}
+}
+
+// Cut 将文本切分为词语，标点和空白丢弃，字母数字串作为一个词
+func (s *Segmenter) Cut(text string) []string {
+	var (
+		words []string
+		han   []rune
+		word  []rune
+	)
+
+	flushHan := func() {
+		if len(han) > 0 {
+			words = append(words, s.cutHan(han)...)
+		}
+		han = han[:0]
+	}
+	flushWord := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+		}
+		word = word[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushHan()
+			flushWord()
+		}
+	}
+	flushHan()
+	flushWord()
+
+	return words
+}
+
+// cutHan 对连续汉字求最大概率切分，随后合并连续的未登录单字
+func (s *Segmenter) cutHan(runes []rune) []string {
+	n := len(runes)
+	logTotal := math.Log(s.total + unknownFreq)
+
+	// best[i] 为 runes[i:] 的最大对数概率，next[i] 为从i开始的词的结束位置
+	best := make([]float64, n+1)
+	next := make([]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		best[i] = math.Inf(-1)
+		for j := i + 1; j <= n && j-i <= s.maxLen; j++ {
+			freq, ok := s.freq[string(runes[i:j])]
+			if !ok {
+				if j-i > 1 {
+					continue
+				}
+				freq = unknownFreq
+			}
+			if score := math.Log(freq) - logTotal + best[j]; score > best[i] {
+				best[i] = score
+				next[i] = j
+			}
+		}
+	}
+
+	var (
+		words   []string
+		unknown []rune
+	)
+	flushUnknown := func() {
+		// 连续2到4个未登录单字多半是人名、地名等新词，合并为一个词
+		if len(unknown) >= 2 && len(unknown) <= 4 {
+			words = append(words, string(unknown))
+		} else {
+			for _, r := range unknown {
+				words = append(words, string(r))
+			}
+		}
+		unknown = unknown[:0]
+	}
+
+	for i := 0; i < n; i = next[i] {
+		word := runes[i:next[i]]
+		if len(word) == 1 {
+			if _, known := s.freq[string(word)]; !known {
+				unknown = append(unknown, word[0])
+				continue
+			}
+		}
+		flushUnknown()
+		words = append(words, string(word))
+	}
+	flushUnknown()
+
+	return words
+}
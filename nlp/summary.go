@@ -0,0 +1,107 @@
+package nlp
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/Lan-ce-lot/data-people/utils"
+)
+
+// Summarizer 抽取式摘要，用TextRank为句子打分，按原文顺序输出得分最高的句子
+type Summarizer struct {
+	extractor *Extractor
+}
+
+// NewSummarizer 创建摘要生成器
+func NewSummarizer(extractor *Extractor) *Summarizer {
+	return &Summarizer{extractor: extractor}
+}
+
+// Summarize 生成不超过maxSentences句、maxLength字的摘要，maxLength<=0表示不限制字数
+// 得分最高的句子即使超过字数限制也会保留，保证摘要不为空
+func (s *Summarizer) Summarize(text string, maxSentences, maxLength int) string {
+	sentences := utils.SplitSentences(text)
+	if len(sentences) == 0 {
+		return ""
+	}
+
+	words := make([][]string, len(sentences))
+	for i, sentence := range sentences {
+		words[i] = s.extractor.Candidates(sentence)
+	}
+
+	graph := make(map[string]map[string]float64, len(sentences))
+	for i := range sentences {
+		graph[sentenceKey(i)] = make(map[string]float64)
+	}
+	for i := range sentences {
+		for j := i + 1; j < len(sentences); j++ {
+			if similarity := sentenceSimilarity(words[i], words[j]); similarity > 0 {
+				graph[sentenceKey(i)][sentenceKey(j)] = similarity
+				graph[sentenceKey(j)][sentenceKey(i)] = similarity
+			}
+		}
+	}
+	scores := pageRank(graph)
+
+	order := make([]int, len(sentences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[sentenceKey(order[a])] > scores[sentenceKey(order[b])]
+	})
+
+	selected := make([]bool, len(sentences))
+	length, count := 0, 0
+	for _, i := range order {
+		if maxSentences > 0 && count >= maxSentences {
+			break
+		}
+		sentenceLength := utf8.RuneCountInString(sentences[i])
+		if count > 0 && maxLength > 0 && length+sentenceLength > maxLength {
+			continue
+		}
+		selected[i] = true
+		length += sentenceLength
+		count++
+	}
+
+	summary := ""
+	for i, sentence := range sentences {
+		if selected[i] {
+			summary += sentence
+		}
+	}
+	return summary
+}
+
+// sentenceKey 句子在词图中的节点名
+func sentenceKey(i int) string {
+	return strconv.Itoa(i)
+}
+
+// sentenceSimilarity 两个句子的相似度：共同词数除以两句词数对数之和
+func sentenceSimilarity(a, b []string) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, word := range a {
+		set[word] = true
+	}
+	common := 0
+	for _, word := range b {
+		if set[word] {
+			common++
+			delete(set, word)
+		}
+	}
+	if common == 0 {
+		return 0
+	}
+	return float64(common) / (math.Log(float64(len(a))) + math.Log(float64(len(b))))
+}
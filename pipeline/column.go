@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+//go:embed data/columns.txt
+var columnsData string
+
+// minContainedColumnLength 栏目名至少这么多字时，才在副标题和特征内容中按包含关系匹配；
+// 较短的栏目名（如钟声、大地）容易误判，只在整体匹配时使用
+const minContainedColumnLength = 3
+
+var (
+	// columnPrefixRes 标题中栏目前缀的写法：【今日谈】、人民时评：、钟声·
+	columnPrefixRes = []*regexp.Regexp{
+		regexp.MustCompile(`^[【\[]([^】\]]{2,12})[】\]]`),
+		regexp.MustCompile(`^(\p{Han}{2,10})\s*[：:]`),
+		regexp.MustCompile(`^(\p{Han}{2,10})\s*[·•]`),
+	}
+	// columnSuffixes 词典以外的栏目名常见的结尾，用于识别未收录的栏目
+	columnSuffixes = []string{
+		"时评", "论坛", "观察", "随笔", "快评", "短评", "手记", "札记", "走笔",
+		"视线", "视点", "关注", "热点", "聚焦", "透视", "纵横", "漫谈", "漫笔", "评论员",
+	}
+)
+
+// ColumnTagger 按栏目词典和标题前缀规则识别文章所属栏目
+type ColumnTagger struct {
+	// aliases 栏目名和别名到栏目名的映射
+	aliases map[string]string
+	// contained 可以按包含关系匹配的名称，按长度降序排列
+	contained []string
+}
+
+// NewColumnTagger 加载内置栏目词典和用户词典，创建栏目识别
+func NewColumnTagger(cfg config.ColumnsConfig) (*ColumnTagger, error) {
+	t := &ColumnTagger{aliases: make(map[string]string)}
+	_ = t.load(strings.NewReader(columnsData))
+
+	if cfg.UserDict != "" {
+		file, err := os.Open(cfg.UserDict)
+		if err != nil {
+			return nil, fmt.Errorf("打开栏目词典失败: %v", err)
+		}
+		defer file.Close()
+		if err := t.load(file); err != nil {
+			return nil, fmt.Errorf("读取栏目词典失败: %v", err)
+		}
+	}
+
+	for name := range t.aliases {
+		if utf8.RuneCountInString(name) >= minContainedColumnLength {
+			t.contained = append(t.contained, name)
+		}
+	}
+	// 优先匹配较长的名称，字数相同时按字典序保证结果稳定
+	sort.Slice(t.contained, func(i, j int) bool {
+		li, lj := utf8.RuneCountInString(t.contained[i]), utf8.RuneCountInString(t.contained[j])
+		if li != lj {
+			return li > lj
+		}
+		return t.contained[i] < t.contained[j]
+	})
+
+	return t, nil
+}
+
+// load 读取栏目词典，每行第一个词是栏目名，其余是别名
+func (t *ColumnTagger) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names := strings.Fields(line)
+		for _, name := range names {
+			t.aliases[name] = names[0]
+		}
+	}
+	return scanner.Err()
+}
+
+// Tag 识别文章栏目，已有栏目的文章不处理
+func (t *ColumnTagger) Tag(article *models.Article) {
+	if article.Column != "" {
+		return
+	}
+	article.Column = t.Detect(article.Title, article.Subtitle, article.Raw)
+}
+
+// Detect 依次从标题前缀、副标题和特征内容识别栏目，识别不到时返回空
+func (t *ColumnTagger) Detect(title, subtitle, raw string) string {
+	if column := t.fromTitle(title); column != "" {
+		return column
+	}
+	for _, text := range []string{subtitle, raw} {
+		if column := t.fromText(text); column != "" {
+			return column
+		}
+	}
+	return ""
+}
+
+// fromTitle 标题前缀是词典中的栏目，或以栏目常见结尾收尾时视为栏目
+func (t *ColumnTagger) fromTitle(title string) string {
+	title = strings.TrimSpace(title)
+	for _, re := range columnPrefixRes {
+		match := re.FindStringSubmatch(title)
+		if match == nil {
+			continue
+		}
+		candidate := strings.TrimSpace(match[1])
+		if column, ok := t.aliases[candidate]; ok {
+			return column
+		}
+		for _, suffix := range columnSuffixes {
+			if strings.HasSuffix(candidate, suffix) && candidate != suffix {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// fromText 副标题或特征内容去掉装饰符号后整体是栏目名，或包含较长的栏目名
+func (t *ColumnTagger) fromText(text string) string {
+	trimmed := strings.Trim(strings.TrimSpace(text), "——-—◆◇●■□·•【】[]（）()《》 　")
+	if trimmed == "" {
+		return ""
+	}
+	if column, ok := t.aliases[trimmed]; ok {
+		return column
+	}
+	for _, name := range t.contained {
+		if strings.Contains(text, name) {
+			return t.aliases[name]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package pipeline
+
+import (
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/nlp"
+)
+
+// KeywordTagger 为文章提取关键词
+type KeywordTagger struct {
+	extractor *nlp.Extractor
+	method    string
+	topK      int
+}
+
+// NewExtractor 加载词典和IDF统计，创建关键词提取器（摘要生成也使用它分词）
+func NewExtractor(cfg config.KeywordsConfig) (*nlp.Extractor, error) {
+	segmenter, err := nlp.NewSegmenter(cfg.UserDict)
+	if err != nil {
+		return nil, err
+	}
+	idf, err := nlp.LoadIDF(cfg.IDFPath)
+	if err != nil {
+		return nil, err
+	}
+	return nlp.NewExtractor(segmenter, idf), nil
+}
+
+// NewKeywordTagger 创建关键词提取
+func NewKeywordTagger(cfg config.KeywordsConfig, extractor *nlp.Extractor) (*KeywordTagger, error) {
+	t := &KeywordTagger{
+		extractor: extractor,
+		method:    cfg.Method,
+		topK:      cfg.TopK,
+	}
+	// 提前检查提取方法是否有效
+	if _, err := t.extractor.Extract("", t.method, t.topK); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Tag 从正文提取关键词，已有关键词的文章不处理
+func (t *KeywordTagger) Tag(article *models.Article) {
+	if article.Keywords != "" {
+		return
+	}
+	keywords, _ := t.extractor.Extract(article.Content, t.method, t.topK)
+	article.Keywords = nlp.JoinKeywords(keywords)
+}
@@ -0,0 +1,184 @@
+package pipeline
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"unicode"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/t2s.txt
+var t2sData string
+
+// t2sTable 繁体字到简体字的对照表，首次使用时加载
+var t2sTable map[rune]rune
+
+// Normalizer 文章文本规范化，作用于标题、副标题和正文
+type Normalizer struct {
+	steps []normalizeStep
+	// markdownSteps 作用于Markdown正文的步骤，不含会破坏列表和表格换行的空白规范化
+	markdownSteps []normalizeStep
+}
+
+// normalizeStep 规范化步骤，multiline为true表示处理的是分段的正文
+type normalizeStep func(text string, multiline bool) string
+
+// NewNormalizer 按配置启用的步骤创建规范化器，步骤顺序固定
+func NewNormalizer(cfg config.NormalizationConfig) *Normalizer {
+	n := &Normalizer{}
+	if cfg.NFKC {
+		n.steps = append(n.steps, normalizeNFKC)
+		n.markdownSteps = append(n.markdownSteps, normalizeNFKC)
+	}
+	if cfg.TraditionalToSimplified {
+		loadT2STable()
+		n.steps = append(n.steps, toSimplified)
+		n.markdownSteps = append(n.markdownSteps, toSimplified)
+	}
+	if cfg.Whitespace {
+		n.steps = append(n.steps, normalizeWhitespace)
+	}
+	if cfg.Punctuation {
+		n.steps = append(n.steps, unifyPunctuation)
+		n.markdownSteps = append(n.markdownSteps, unifyPunctuation)
+	}
+	return n
+}
+
+// Normalize 就地规范化文章的标题、副标题、正文和Markdown正文
+func (n *Normalizer) Normalize(article *models.Article) {
+	for _, step := range n.steps {
+		article.Title = step(article.Title, false)
+		article.Subtitle = step(article.Subtitle, false)
+		article.Content = step(article.Content, true)
+	}
+	for _, step := range n.markdownSteps {
+		article.ContentMarkdown = step(article.ContentMarkdown, true)
+	}
+}
+
+// nfkcPreserved NFKC会把这些中文标点转换为半角或拆成多个字符，需要原样保留
+var nfkcPreserved = map[rune]bool{
+	'，': true, '：': true, '；': true, '？': true, '！': true,
+	'（': true, '）': true, '…': true, '‥': true,
+}
+
+// normalizeNFKC Unicode NFKC规范化：全角字母数字转半角、全角空格转普通空格、兼容汉字转标准汉字
+func normalizeNFKC(text string, multiline bool) string {
+	var builder strings.Builder
+	builder.Grow(len(text))
+
+	start := 0
+	for i, r := range text {
+		if nfkcPreserved[r] {
+			builder.WriteString(norm.NFKC.String(text[start:i]))
+			builder.WriteRune(r)
+			start = i + len(string(r))
+		}
+	}
+	builder.WriteString(norm.NFKC.String(text[start:]))
+
+	return builder.String()
+}
+
+// loadT2STable 解析内嵌的繁简对照表
+func loadT2STable() {
+	if t2sTable != nil {
+		return
+	}
+
+	table := make(map[rune]rune)
+	scanner := bufio.NewScanner(strings.NewReader(t2sData))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		traditional, simplified := []rune(fields[0]), []rune(fields[1])
+		if len(traditional) == 1 && len(simplified) == 1 {
+			table[traditional[0]] = simplified[0]
+		}
+	}
+	t2sTable = table
+}
+
+// toSimplified 按对照表逐字将繁体字转换为简体字
+func toSimplified(text string, multiline bool) string {
+	return strings.Map(func(r rune) rune {
+		if simplified, ok := t2sTable[r]; ok {
+			return simplified
+		}
+		return r
+	}, text)
+}
+
+// normalizeWhitespace 合并连续空白并去掉首尾空白
+// 正文按行切分为段落，去掉空段落后用空行连接；标题和副标题合并为一行
+func normalizeWhitespace(text string, multiline bool) string {
+	if !multiline {
+		return strings.Join(strings.Fields(text), " ")
+	}
+
+	var paragraphs []string
+	for _, line := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		if paragraph := strings.Join(strings.Fields(line), " "); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// fullWidthPunctuation 中文语境下半角标点对应的全角标点
+var fullWidthPunctuation = map[rune]rune{
+	',': '，', ':': '：', ';': '；', '?': '？', '!': '！', '(': '（', ')': '）',
+}
+
+// unifyPunctuation 统一标点宽度：紧邻汉字的半角标点转换为全角，西文和数字之间的标点保持半角
+func unifyPunctuation(text string, multiline bool) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		fullWidth, ok := fullWidthPunctuation[r]
+		if !ok {
+			continue
+		}
+		if isHanAt(runes, i-1) || isHanAt(runes, i+1) {
+			runes[i] = fullWidth
+		}
+	}
+
+	result := string(runes)
+	if strings.Contains(result, "...") {
+		result = replaceHanEllipsis(result)
+	}
+	return result
+}
+
+// replaceHanEllipsis 将汉字之后的半角省略号"..."转换为"……"
+func replaceHanEllipsis(text string) string {
+	var builder strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if i+2 < len(runes) && runes[i] == '.' && runes[i+1] == '.' && runes[i+2] == '.' && isHanAt(runes, i-1) {
+			builder.WriteString("……")
+			for i+1 < len(runes) && runes[i+1] == '.' {
+				i++
+			}
+			continue
+		}
+		builder.WriteRune(runes[i])
+	}
+	return builder.String()
+}
+
+// isHanAt 判断位置i的字符是否为汉字，越界返回false
+func isHanAt(runes []rune, i int) bool {
+	return i >= 0 && i < len(runes) && unicode.Is(unicode.Han, runes[i])
+}
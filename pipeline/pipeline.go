@@ -0,0 +1,140 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/storage"
+	"github.com/Lan-ce-lot/data-people/utils"
+)
+
+// Pipeline 解析与存储之间的处理流程
+type Pipeline struct {
+	normalizer *Normalizer
+	validator  *Validator
+	quarantine *storage.QuarantineStorage
+	keywords   *KeywordTagger
+	summary    *SummaryTagger
+	columns    *ColumnTagger
+}
+
+// New 根据配置创建处理流程
+func New(cfg *config.Config) (*Pipeline, error) {
+	p := &Pipeline{}
+
+	if cfg.Normalization.Enabled {
+		p.normalizer = NewNormalizer(cfg.Normalization)
+	}
+
+	if cfg.Validation.Enabled {
+		validator, err := NewValidator(cfg.Validation)
+		if err != nil {
+			return nil, err
+		}
+		p.validator = validator
+		p.quarantine = storage.NewQuarantineStorage(cfg.Validation.QuarantineDir)
+	}
+
+	if cfg.Keywords.Enabled || cfg.Summary.Enabled {
+		extractor, err := NewExtractor(cfg.Keywords)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Keywords.Enabled {
+			if p.keywords, err = NewKeywordTagger(cfg.Keywords, extractor); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Summary.Enabled {
+			p.summary = NewSummaryTagger(cfg.Summary, extractor)
+		}
+	}
+
+	if cfg.Columns.Enabled {
+		columns, err := NewColumnTagger(cfg.Columns)
+		if err != nil {
+			return nil, err
+		}
+		p.columns = columns
+	}
+
+	return p, nil
+}
+
+// Init 初始化处理流程依赖的存储
+func (p *Pipeline) Init() error {
+	if p.quarantine != nil {
+		return p.quarantine.Init()
+	}
+	return nil
+}
+
+// Process 处理一批文章：先规范化文本并计算规范ID，再校验，为通过校验的文章提取关键词、摘要和栏目后返回；未通过的文章写入隔离区
+func (p *Pipeline) Process(articles []*models.Article, window utils.DateRange) ([]*models.Article, []*models.QuarantinedArticle, error) {
+	p.Identify(articles)
+
+	accepted, rejected, err := p.validate(articles, window)
+
+	for _, article := range accepted {
+		p.Enrich(article)
+	}
+
+	return accepted, rejected, err
+}
+
+// Identify 规范化文本并计算规范ID，抓取时可在保存前据此判断文章是否已存储
+func (p *Pipeline) Identify(articles []*models.Article) {
+	for _, article := range articles {
+		if p.normalizer != nil {
+			p.normalizer.Normalize(article)
+		}
+		// 哈希ID依赖标题，在规范化之后计算，与存储的标题一致
+		article.EnsureID()
+	}
+}
+
+// Enrich 为文章填充关键词、摘要和栏目等派生字段，已有值的字段不处理
+func (p *Pipeline) Enrich(article *models.Article) {
+	if p.keywords != nil {
+		p.keywords.Tag(article)
+	}
+	if p.summary != nil {
+		p.summary.Tag(article)
+	}
+	if p.columns != nil {
+		p.columns.Tag(article)
+	}
+}
+
+// validate 校验文章，未通过的文章写入隔离区
+func (p *Pipeline) validate(articles []*models.Article, window utils.DateRange) ([]*models.Article, []*models.QuarantinedArticle, error) {
+	if p.validator == nil {
+		return articles, nil, nil
+	}
+
+	var (
+		accepted []*models.Article
+		rejected []*models.QuarantinedArticle
+	)
+	for _, article := range articles {
+		reasons := p.validator.Validate(article, window)
+		if len(reasons) == 0 {
+			accepted = append(accepted, article)
+			continue
+		}
+
+		rejected = append(rejected, &models.QuarantinedArticle{
+			Article:       *article,
+			Reasons:       reasons,
+			WindowStart:   window.Start.Format("2006-01-02"),
+			WindowEnd:     window.End.Format("2006-01-02"),
+			QuarantinedAt: time.Now(),
+		})
+	}
+
+	if err := p.quarantine.SaveBatch(rejected); err != nil {
+		return accepted, rejected, err
+	}
+	return accepted, rejected, nil
+}
@@ -0,0 +1,31 @@
+package pipeline
+
+import (
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/nlp"
+)
+
+// SummaryTagger 为文章生成抽取式摘要
+type SummaryTagger struct {
+	summarizer   *nlp.Summarizer
+	maxSentences int
+	maxLength    int
+}
+
+// NewSummaryTagger 创建摘要生成
+func NewSummaryTagger(cfg config.SummaryConfig, extractor *nlp.Extractor) *SummaryTagger {
+	return &SummaryTagger{
+		summarizer:   nlp.NewSummarizer(extractor),
+		maxSentences: cfg.MaxSentences,
+		maxLength:    cfg.MaxLength,
+	}
+}
+
+// Tag 从正文生成摘要，已有摘要的文章不处理
+func (t *SummaryTagger) Tag(article *models.Article) {
+	if article.Summary != "" {
+		return
+	}
+	article.Summary = t.summarizer.Summarize(article.Content, t.maxSentences, t.maxLength)
+}
@@ -0,0 +1,81 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Lan-ce-lot/data-people/config"
+	"github.com/Lan-ce-lot/data-people/models"
+	"github.com/Lan-ce-lot/data-people/utils"
+)
+
+// Validator 文章数据校验器
+type Validator struct {
+	cfg       config.ValidationConfig
+	editionRe *regexp.Regexp
+}
+
+// NewValidator 创建校验器
+func NewValidator(cfg config.ValidationConfig) (*Validator, error) {
+	v := &Validator{cfg: cfg}
+
+	for _, field := range cfg.RequiredFields {
+		if _, ok := requiredChecks[field]; !ok {
+			return nil, fmt.Errorf("不支持的必填字段: %s", field)
+		}
+	}
+
+	if cfg.EditionPattern != "" {
+		re, err := regexp.Compile(cfg.EditionPattern)
+		if err != nil {
+			return nil, fmt.Errorf("版次格式正则无效: %v", err)
+		}
+		v.editionRe = re
+	}
+
+	return v, nil
+}
+
+// requiredChecks 必填字段的检查函数，返回true表示字段有值
+var requiredChecks = map[string]func(article *models.Article) bool{
+	"url":          func(a *models.Article) bool { return strings.TrimSpace(a.URL) != "" },
+	"title":        func(a *models.Article) bool { return strings.TrimSpace(a.Title) != "" },
+	"subtitle":     func(a *models.Article) bool { return strings.TrimSpace(a.Subtitle) != "" },
+	"content":      func(a *models.Article) bool { return strings.TrimSpace(a.Content) != "" },
+	"publish_date": func(a *models.Article) bool { return !a.PublishDate.IsZero() },
+	"edition":      func(a *models.Article) bool { return strings.TrimSpace(a.Edition) != "" },
+	"type":         func(a *models.Article) bool { return strings.TrimSpace(a.Type) != "" },
+}
+
+// Validate 校验文章，返回未通过的规则说明，空切片表示通过
+func (v *Validator) Validate(article *models.Article, window utils.DateRange) []string {
+	var reasons []string
+
+	for _, field := range v.cfg.RequiredFields {
+		if !requiredChecks[field](article) {
+			reasons = append(reasons, fmt.Sprintf("缺少字段%s", field))
+		}
+	}
+
+	if v.cfg.MinContentLength > 0 && strings.TrimSpace(article.Content) != "" {
+		if length := utf8.RuneCountInString(strings.TrimSpace(article.Content)); length < v.cfg.MinContentLength {
+			reasons = append(reasons, fmt.Sprintf("正文过短(%d字，至少%d字)", length, v.cfg.MinContentLength))
+		}
+	}
+
+	if v.cfg.CheckDateWindow && !article.PublishDate.IsZero() && !window.Start.IsZero() {
+		windowEnd := window.End.AddDate(0, 0, 1)
+		if article.PublishDate.Before(window.Start) || !article.PublishDate.Before(windowEnd) {
+			reasons = append(reasons, fmt.Sprintf("发布日期%s不在抓取时间段%s内",
+				article.PublishDate.Format("2006-01-02"), window.String()))
+		}
+	}
+
+	if v.editionRe != nil && article.Edition != "" && !v.editionRe.MatchString(article.Edition) {
+		reasons = append(reasons, fmt.Sprintf("版次格式错误: %s", article.Edition))
+	}
+
+	return reasons
+}
@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros 常用的预定义表达式
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// monthNames 月份字段可用的英文缩写
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// weekdayNames 星期字段可用的英文缩写
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// maxSearchYears 查找下一次触发时间的最大年数，超过则认为表达式不会触发（如2月30日）
+const maxSearchYears = 5
+
+// Schedule 解析后的5字段cron表达式：分 时 日 月 星期
+type Schedule struct {
+	expr    string
+	minute  uint64 // 0-59
+	hour    uint64 // 0-23
+	day     uint64 // 1-31
+	month   uint64 // 1-12
+	weekday uint64 // 0-6，0为星期日
+
+	// 日和星期都有限制时，按标准cron语义满足其一即可
+	dayRestricted, weekdayRestricted bool
+}
+
+// cronField 字段的取值范围和可用名称
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "分钟", min: 0, max: 59},
+	{name: "小时", min: 0, max: 23},
+	{name: "日", min: 1, max: 31},
+	{name: "月", min: 1, max: 12, names: monthNames},
+	{name: "星期", min: 0, max: 7, names: weekdayNames},
+}
+
+// ParseCron 解析5字段cron表达式，支持 * , - / 、月份和星期的英文缩写以及 @daily 等预定义表达式
+// 星期字段中0和7都表示星期日
+func ParseCron(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron表达式需要5个字段 (分 时 日 月 星期): %q", expr)
+	}
+
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		bits, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("解析cron表达式失败 %q: %v", expr, err)
+		}
+		values[i] = bits
+	}
+
+	// 7也表示星期日
+	if values[4]&(1<<7) != 0 {
+		values[4] = values[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		expr:              expr,
+		minute:            values[0],
+		hour:              values[1],
+		day:               values[2],
+		month:             values[3],
+		weekday:           values[4],
+		dayRestricted:     fields[2] != "*" && !strings.HasPrefix(fields[2], "*/"),
+		weekdayRestricted: fields[4] != "*" && !strings.HasPrefix(fields[4], "*/"),
+	}, nil
+}
+
+// parseCronField 将一个字段解析为取值位图，字段由逗号分隔的 *、a、a-b 组成，每项可带 /步长
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s字段步长无效: %q", spec.name, part)
+			}
+			step = n
+		}
+
+		start, end := spec.min, spec.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = cronValue(bounds[0], spec); err != nil {
+				return 0, err
+			}
+			if end, err = cronValue(bounds[1], spec); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("%s字段范围无效: %q", spec.name, rangePart)
+			}
+		default:
+			value, err := cronValue(rangePart, spec)
+			if err != nil {
+				return 0, err
+			}
+			start = value
+			// 单个值带步长时表示从该值开始到最大值，如 5/15
+			if step == 1 {
+				end = value
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+// cronValue 解析字段中的单个数值或英文缩写并检查范围
+func cronValue(text string, spec cronField) (int, error) {
+	if value, ok := spec.names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s字段取值无效: %q", spec.name, text)
+	}
+	if value < spec.min || value > spec.max {
+		return 0, fmt.Errorf("%s字段取值超出范围 %d-%d: %d", spec.name, spec.min, spec.max, value)
+	}
+	return value, nil
+}
+
+// String 返回原始表达式
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next 返回after之后（不含）的下一次触发时间，精确到分钟，使用after所在的时区
+// 表达式在若干年内都不会触发时返回零值
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchDay 判断日期是否满足日和星期字段
+func (s *Schedule) matchDay(t time.Time) bool {
+	dayMatch := s.day&(1<<uint(t.Day())) != 0
+	weekdayMatch := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.dayRestricted && s.weekdayRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
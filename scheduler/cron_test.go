@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	cases := []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"* * * * funday",
+		"@every 5m",
+	}
+
+	for _, expr := range cases {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) 没有返回错误", expr)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	// 2024-01-01 是星期一
+	cases := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"步长", "*/15 * * * *", at(2024, 1, 1, 10, 7), at(2024, 1, 1, 10, 15)},
+		{"单个值带步长", "5/20 * * * *", at(2024, 1, 1, 10, 7), at(2024, 1, 1, 10, 25)},
+		{"范围带步长跨天", "0 9-17/4 * * *", at(2024, 1, 1, 17, 30), at(2024, 1, 2, 9, 0)},
+		{"不含after本身", "@hourly", at(2024, 1, 1, 10, 0), at(2024, 1, 1, 11, 0)},
+		{"忽略秒", "@hourly", at(2024, 1, 1, 10, 59).Add(30 * time.Second), at(2024, 1, 1, 11, 0)},
+		{"@daily", "@daily", at(2024, 1, 1, 10, 0), at(2024, 1, 2, 0, 0)},
+		{"@weekly为星期日", "@weekly", at(2024, 1, 1, 0, 0), at(2024, 1, 7, 0, 0)},
+		{"@monthly", "@monthly", at(2024, 1, 15, 0, 0), at(2024, 2, 1, 0, 0)},
+		{"@yearly", "@YEARLY", at(2024, 3, 1, 0, 0), at(2025, 1, 1, 0, 0)},
+		{"7表示星期日", "0 0 * * 7", at(2024, 1, 1, 0, 0), at(2024, 1, 7, 0, 0)},
+		{"星期缩写", "0 0 * * sun", at(2024, 1, 1, 0, 0), at(2024, 1, 7, 0, 0)},
+		{"星期范围到7", "0 0 * * 5-7", at(2024, 1, 1, 0, 0), at(2024, 1, 5, 0, 0)},
+		{"月份缩写", "30 6 * jan,jul *", at(2024, 1, 31, 7, 0), at(2024, 7, 1, 6, 30)},
+		{"只限制日", "0 0 13 * *", at(2024, 1, 1, 0, 0), at(2024, 1, 13, 0, 0)},
+		{"日或星期-先到星期五", "0 0 13 * 5", at(2024, 1, 1, 0, 0), at(2024, 1, 5, 0, 0)},
+		{"日或星期-下一个星期五", "0 0 13 * 5", at(2024, 1, 5, 0, 0), at(2024, 1, 12, 0, 0)},
+		{"日或星期-13日", "0 0 13 * 5", at(2024, 1, 12, 0, 0), at(2024, 1, 13, 0, 0)},
+		{"星期为*/步长时与日同时满足", "0 0 1 * */2", at(2024, 1, 1, 0, 0), at(2024, 2, 1, 0, 0)},
+		{"闰日", "0 0 29 2 *", at(2024, 3, 1, 0, 0), at(2028, 2, 29, 0, 0)},
+		{"不会触发", "0 0 30 2 *", at(2024, 1, 1, 0, 0), time.Time{}},
+		{"使用after的时区", "0 8 * * *",
+			time.Date(2024, 1, 1, 9, 0, 0, 0, shanghai), time.Date(2024, 1, 2, 8, 0, 0, 0, shanghai)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) 失败: %v", tc.expr, err)
+			}
+			if got := schedule.Next(tc.after); !got.Equal(tc.want) {
+				t.Errorf("Next(%v) = %v, 期望 %v", tc.after, got, tc.want)
+			}
+		})
+	}
+}
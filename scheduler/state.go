@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// JobStatusFailed 抓取未能开始，如初始化存储失败或已有抓取在运行；其他状态与抓取运行记录相同
+const JobStatusFailed = "failed"
+
+// JobState 定时任务最近一次运行的状态
+type JobState struct {
+	LastStart  time.Time `json:"last_start"`
+	LastEnd    time.Time `json:"last_end"`
+	LastStatus string    `json:"last_status"`           // completed、interrupted 或 failed
+	LastRunID  string    `json:"last_run_id,omitempty"` // 对应的抓取运行ID，见 runs 命令
+	LastError  string    `json:"last_error,omitempty"`
+}
+
+// State 守护进程状态，按任务名称记录，重启后据此补上停机期间错过的运行
+type State struct {
+	path string
+	Jobs map[string]*JobState `json:"jobs"`
+}
+
+// LoadState 读取状态文件，文件不存在时返回空状态
+func LoadState(path string) (*State, error) {
+	state := &State{path: path, Jobs: make(map[string]*JobState)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取状态文件失败: %v", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("解析状态文件失败: %v", err)
+	}
+	if state.Jobs == nil {
+		state.Jobs = make(map[string]*JobState)
+	}
+	return state, nil
+}
+
+// Job 返回任务的状态，没有运行过时返回nil
+func (s *State) Job(name string) *JobState {
+	return s.Jobs[name]
+}
+
+// Record 记录任务的一次运行并写入状态文件
+func (s *State) Record(name string, job *JobState) error {
+	s.Jobs[name] = job
+	return s.Save()
+}
+
+// Save 写入状态文件，先写临时文件再重命名，避免中断时留下不完整的文件
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化状态失败: %v", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建状态目录失败: %v", err)
+		}
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("写入状态文件失败: %v", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("保存状态文件失败: %v", err)
+	}
+	return nil
+}
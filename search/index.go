@@ -0,0 +1,508 @@
+package search
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+const (
+	// titleWeight 标题中的词频权重
+	titleWeight = 3
+
+	// BM25参数
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// indexVersion 索引格式版本，2起为每个汉字索引单字
+	indexVersion = 2
+)
+
+// Document 索引中的文档
+type Document struct {
+	ArticleID   int64 // 文章规范ID
+	URL         string
+	Title       string
+	PublishDate time.Time
+	Edition     string
+	Type        string
+	Content     string
+	Length      int
+	Deleted     bool
+}
+
+// Posting 倒排表项
+type Posting struct {
+	Doc int32
+	TF  uint16
+}
+
+// Index 倒排索引
+// 索引文件是某一时刻的快照，之后新增的文章追加到日志文件（索引文件加 .log 后缀），打开时重放
+type Index struct {
+	mu        sync.RWMutex
+	path      string
+	Version   int
+	Docs      []Document
+	Postings  map[string][]Posting
+	TotalLen  int64
+	byID      map[int64]int
+	journaled int // 日志中的文章数
+}
+
+// journalEntry 日志中的一篇文章
+type journalEntry struct {
+	ID          int64     `json:"id"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	PublishDate time.Time `json:"publish_date"`
+	Edition     string    `json:"edition"`
+	Type        string    `json:"type"`
+	Content     string    `json:"content"`
+}
+
+// Options 检索选项
+type Options struct {
+	From  time.Time // 发布日期下限（含）
+	To    time.Time // 发布日期上限（含当天）
+	Limit int
+}
+
+// Hit 检索结果
+type Hit struct {
+	ID          int64     `json:"id"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	PublishDate time.Time `json:"publish_date"`
+	Edition     string    `json:"edition"`
+	Type        string    `json:"type"`
+	Score       float64   `json:"score"`
+	Snippet     string    `json:"snippet"`
+}
+
+// Open 打开索引文件并重放日志，文件不存在时返回空索引
+func Open(path string) (*Index, error) {
+	index := NewIndex(path)
+	if err := index.load(); err != nil {
+		return nil, err
+	}
+	if err := index.replay(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// load 读取索引快照
+func (idx *Index) load() error {
+	file, err := os.Open(idx.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("打开索引文件失败: %v", err)
+	}
+	defer file.Close()
+
+	idx.Version = 0 // 早期索引文件没有版本号
+	if err := gob.NewDecoder(file).Decode(idx); err != nil {
+		return fmt.Errorf("读取索引文件失败: %v", err)
+	}
+	if idx.Version < indexVersion {
+		return fmt.Errorf("索引文件格式已更新，请运行 search rebuild 重建: %s", idx.path)
+	}
+	for i := range idx.Docs {
+		if !idx.Docs[i].Deleted {
+			idx.byID[idx.Docs[i].ArticleID] = i
+		}
+	}
+
+	return nil
+}
+
+// journalPath 日志文件路径
+func (idx *Index) journalPath() string {
+	return idx.path + ".log"
+}
+
+// replay 将日志中的文章加入索引
+// 异常退出时最后一行可能不完整，忽略无法解析的末行
+func (idx *Index) replay() error {
+	file, err := os.Open(idx.journalPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("打开索引日志失败: %v", err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取索引日志失败: %v", err)
+	}
+
+	for i, line := range lines {
+		var entry journalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			if i == len(lines)-1 {
+				break
+			}
+			return fmt.Errorf("解析索引日志失败: %v", err)
+		}
+		idx.Add(&models.Article{
+			ID:          entry.ID,
+			URL:         entry.URL,
+			Title:       entry.Title,
+			PublishDate: entry.PublishDate,
+			Edition:     entry.Edition,
+			Type:        entry.Type,
+			Content:     entry.Content,
+		})
+		idx.journaled++
+	}
+	return nil
+}
+
+// Journal 将已加入索引的文章追加到日志文件，写入量只与文章数有关
+func (idx *Index) Journal(articles []*models.Article) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(idx.path), 0755); err != nil {
+		return fmt.Errorf("创建索引目录失败: %v", err)
+	}
+	file, err := os.OpenFile(idx.journalPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("打开索引日志失败: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, article := range articles {
+		if err := encoder.Encode(journalEntry{
+			ID:          article.ID,
+			URL:         article.URL,
+			Title:       article.Title,
+			PublishDate: article.PublishDate,
+			Edition:     article.Edition,
+			Type:        article.Type,
+			Content:     article.Content,
+		}); err != nil {
+			return fmt.Errorf("写入索引日志失败: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("写入索引日志失败: %v", err)
+	}
+	idx.journaled += len(articles)
+	return nil
+}
+
+// Journaled 返回上次写入索引文件后日志中的文章数
+func (idx *Index) Journaled() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.journaled
+}
+
+// NewIndex 创建空索引
+func NewIndex(path string) *Index {
+	return &Index{
+		path:     path,
+		Version:  indexVersion,
+		Postings: make(map[string][]Posting),
+		byID:     make(map[int64]int),
+	}
+}
+
+// Len 返回有效文档数
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.byID)
+}
+
+// Has 返回已索引的规范ID集合
+func (idx *Index) Has(ids []int64) map[int64]bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	found := make(map[int64]bool)
+	for _, id := range ids {
+		if _, ok := idx.byID[id]; ok {
+			found[id] = true
+		}
+	}
+	return found
+}
+
+// Add 添加或替换文章，相同规范ID的旧文档被标记删除
+func (idx *Index) Add(article *models.Article) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	article.EnsureID()
+	if old, exists := idx.byID[article.ID]; exists {
+		idx.Docs[old].Deleted = true
+		idx.TotalLen -= int64(idx.Docs[old].Length)
+	}
+
+	termFreq := make(map[string]int)
+	for _, token := range Tokenize(article.Title) {
+		termFreq[token] += titleWeight
+	}
+	contentTokens := Tokenize(article.Content)
+	for _, token := range contentTokens {
+		termFreq[token]++
+	}
+
+	docID := int32(len(idx.Docs))
+	length := 0
+	for token, tf := range termFreq {
+		if tf > math.MaxUint16 {
+			tf = math.MaxUint16
+		}
+		idx.Postings[token] = append(idx.Postings[token], Posting{Doc: docID, TF: uint16(tf)})
+		length += tf
+	}
+
+	idx.Docs = append(idx.Docs, Document{
+		ArticleID:   article.ID,
+		URL:         article.URL,
+		Title:       article.Title,
+		PublishDate: article.PublishDate,
+		Edition:     article.Edition,
+		Type:        article.Type,
+		Content:     article.Content,
+		Length:      length,
+	})
+	idx.byID[article.ID] = int(docID)
+	idx.TotalLen += int64(length)
+}
+
+// Save 压缩已删除的文档后将索引写入文件（先写临时文件再替换），并清空日志
+func (idx *Index) Save() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.compact()
+
+	if err := os.MkdirAll(filepath.Dir(idx.path), 0755); err != nil {
+		return fmt.Errorf("创建索引目录失败: %v", err)
+	}
+
+	tmpPath := idx.path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("创建索引文件失败: %v", err)
+	}
+
+	if err := gob.NewEncoder(file).Encode(idx); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入索引文件失败: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("关闭索引文件失败: %v", err)
+	}
+
+	if err := os.Rename(tmpPath, idx.path); err != nil {
+		return fmt.Errorf("替换索引文件失败: %v", err)
+	}
+	if err := os.Remove(idx.journalPath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("清空索引日志失败: %v", err)
+	}
+	idx.journaled = 0
+	return nil
+}
+
+// compact 移除已删除的文档及其倒排表项，重新编号
+func (idx *Index) compact() {
+	if len(idx.byID) == len(idx.Docs) {
+		return
+	}
+
+	remap := make([]int32, len(idx.Docs))
+	docs := make([]Document, 0, len(idx.byID))
+	for i := range idx.Docs {
+		if idx.Docs[i].Deleted {
+			remap[i] = -1
+			continue
+		}
+		remap[i] = int32(len(docs))
+		idx.byID[idx.Docs[i].ArticleID] = len(docs)
+		docs = append(docs, idx.Docs[i])
+	}
+	idx.Docs = docs
+
+	for token, postings := range idx.Postings {
+		kept := postings[:0]
+		for _, posting := range postings {
+			if doc := remap[posting.Doc]; doc >= 0 {
+				posting.Doc = doc
+				kept = append(kept, posting)
+			}
+		}
+		if len(kept) == 0 {
+			delete(idx.Postings, token)
+			continue
+		}
+		idx.Postings[token] = kept
+	}
+}
+
+// Search 检索文章，返回按相关度排序的结果和命中总数
+// 查询按空白分隔为多个词组，所有词组都必须出现在标题或正文中
+func (idx *Index) Search(query string, opts Options) ([]Hit, int) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	terms := queryTerms(query)
+	if len(terms) == 0 || len(idx.byID) == 0 {
+		return nil, 0
+	}
+
+	tokenSet := make(map[string]bool)
+	for _, term := range terms {
+		for _, token := range queryTokens(term) {
+			tokenSet[token] = true
+		}
+	}
+
+	// 从最短的倒排表开始求交集
+	tokens := make([]string, 0, len(tokenSet))
+	for token := range tokenSet {
+		if len(idx.Postings[token]) == 0 {
+			return nil, 0
+		}
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return len(idx.Postings[tokens[i]]) < len(idx.Postings[tokens[j]])
+	})
+
+	scores := make(map[int32]float64)
+	for _, posting := range idx.Postings[tokens[0]] {
+		if idx.accept(posting.Doc, opts) {
+			scores[posting.Doc] = 0
+		}
+	}
+
+	docCount := float64(len(idx.byID))
+	avgLen := float64(idx.TotalLen) / docCount
+	for _, token := range tokens {
+		postings := idx.Postings[token]
+		docFreq := float64(idx.liveCount(postings))
+		idf := math.Log(1 + (docCount-docFreq+0.5)/(docFreq+0.5))
+
+		matched := make(map[int32]bool, len(scores))
+		for _, posting := range postings {
+			score, exists := scores[posting.Doc]
+			if !exists {
+				continue
+			}
+			tf := float64(posting.TF)
+			docLen := float64(idx.Docs[posting.Doc].Length)
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLen/avgLen))
+			scores[posting.Doc] = score
+			matched[posting.Doc] = true
+		}
+		for doc := range scores {
+			if !matched[doc] {
+				delete(scores, doc)
+			}
+		}
+	}
+
+	// 二元组命中不代表整个词组相邻出现，逐篇核对原文
+	var hits []Hit
+	for doc, score := range scores {
+		document := &idx.Docs[doc]
+		if !containsAll(document.Title+"\n"+document.Content, terms) {
+			continue
+		}
+		hits = append(hits, Hit{
+			ID:          document.ArticleID,
+			URL:         document.URL,
+			Title:       document.Title,
+			PublishDate: document.PublishDate,
+			Edition:     document.Edition,
+			Type:        document.Type,
+			Score:       score,
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].PublishDate.Before(hits[j].PublishDate)
+	})
+
+	total := len(hits)
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+	for i := range hits {
+		document := &idx.Docs[idx.byID[hits[i].ID]]
+		hits[i].Snippet = Snippet(document.Content, terms, 40)
+	}
+
+	return hits, total
+}
+
+// liveCount 统计倒排表中未删除的文档数，用于计算文档频率
+func (idx *Index) liveCount(postings []Posting) int {
+	count := 0
+	for _, posting := range postings {
+		if !idx.Docs[posting.Doc].Deleted {
+			count++
+		}
+	}
+	return count
+}
+
+// accept 判断文档是否有效且在日期范围内
+func (idx *Index) accept(doc int32, opts Options) bool {
+	document := &idx.Docs[doc]
+	if document.Deleted {
+		return false
+	}
+	if !opts.From.IsZero() && document.PublishDate.Before(opts.From) {
+		return false
+	}
+	if !opts.To.IsZero() && !document.PublishDate.Before(opts.To.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// containsAll 判断文本是否包含全部词组（字母不区分大小写）
+func containsAll(text string, terms []string) bool {
+	lower := strings.ToLower(text)
+	for _, term := range terms {
+		if !strings.Contains(lower, strings.ToLower(term)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// testDay 2024年1月的某一天
+func testDay(day int) time.Time {
+	return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+}
+
+// testArticles 检索用的样例文章
+func testArticles() []*models.Article {
+	return []*models.Article{
+		{ID: 1, Title: "经济形势分析", Content: "今年经济运行总体平稳。", PublishDate: testDay(1)},
+		{ID: 2, Title: "会议召开", Content: "会议讨论了经济问题。", PublishDate: testDay(2)},
+		{ID: 3, Title: "天气预报", Content: "明天晴，气温回升。", PublishDate: testDay(3)},
+		// 包含"经济发展"的全部二元组，但词组没有相邻出现
+		{ID: 4, Title: "短评", Content: "经济。济发。发展。", PublishDate: testDay(4)},
+		{ID: 5, Title: "统计公报", Content: "全年GDP增长5%，经济发展稳中向好。", PublishDate: testDay(5)},
+		{ID: 6, Title: "统计公报", Content: "全年GDP增长5%，经济发展稳中向好。", PublishDate: testDay(6)},
+	}
+}
+
+// newTestIndex 创建包含样例文章的内存索引
+func newTestIndex() *Index {
+	index := NewIndex("")
+	for _, article := range testArticles() {
+		index.Add(article)
+	}
+	return index
+}
+
+// hitIDs 检索结果的规范ID
+func hitIDs(hits []Hit) []int64 {
+	var ids []int64
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		name  string
+		text  string
+		index string // Tokenize 的结果
+		query string // queryTokens 的结果
+	}{
+		{"汉字", "人民日报", "人 民 日 报 人民 民日 日报", "人民 民日 日报"},
+		{"单个汉字", "国", "国", "国"},
+		{"字母数字转小写", "GDP增长5%", "gdp 增 长 增长 5", "gdp 增长 5"},
+		{"标点分隔", "经济，发展", "经 济 经济 发 展 发展", "经济 发展"},
+		{"没有索引词", "，。！", "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := strings.Join(Tokenize(tc.text), " "); got != tc.index {
+				t.Errorf("Tokenize(%q) = %q, 期望 %q", tc.text, got, tc.index)
+			}
+			if got := strings.Join(queryTokens(tc.text), " "); got != tc.query {
+				t.Errorf("queryTokens(%q) = %q, 期望 %q", tc.text, got, tc.query)
+			}
+		})
+	}
+}
+
+func TestContainsAll(t *testing.T) {
+	cases := []struct {
+		text  string
+		terms []string
+		want  bool
+	}{
+		{"经济发展稳中向好", []string{"经济发展"}, true},
+		{"经济。济发。发展。", []string{"经济发展"}, false},
+		{"全年GDP增长", []string{"gdp", "增长"}, true},
+		{"全年GDP增长", []string{"GDP", "下降"}, false},
+		{"任意文本", nil, true},
+	}
+
+	for _, tc := range cases {
+		if got := containsAll(tc.text, tc.terms); got != tc.want {
+			t.Errorf("containsAll(%q, %q) = %v, 期望 %v", tc.text, tc.terms, got, tc.want)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		opts  Options
+		want  []int64
+		total int
+	}{
+		// 标题中的词权重更高；词频相同时较短的文章在前；得分相同时较早的文章在前
+		{"BM25排序", "经济", Options{}, []int64{1, 4, 2, 5, 6}, 5},
+		{"词组必须相邻出现", "经济发展", Options{}, []int64{5, 6}, 2},
+		{"多个词组都要命中且不区分大小写", "gdp 向好", Options{}, []int64{5, 6}, 2},
+		{"有词组没有命中", "经济 下雪", Options{}, nil, 0},
+		{"日期范围含结束当天", "经济", Options{From: testDay(2), To: testDay(5)}, []int64{4, 2, 5}, 3},
+		{"限制条数不影响总数", "经济", Options{Limit: 2}, []int64{1, 4}, 5},
+		{"空查询", " ，", Options{}, nil, 0},
+	}
+
+	index := newTestIndex()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, total := index.Search(tc.query, tc.opts)
+			if got := hitIDs(hits); !equalIDs(got, tc.want) || total != tc.total {
+				t.Errorf("Search(%q) = %v (共%d), 期望 %v (共%d)", tc.query, got, total, tc.want, tc.total)
+			}
+		})
+	}
+}
+
+func TestAddReplaces(t *testing.T) {
+	index := newTestIndex()
+	index.Add(&models.Article{ID: 3, Title: "天气预报", Content: "明天有雪。", PublishDate: testDay(3)})
+
+	if index.Len() != len(testArticles()) {
+		t.Errorf("文档数 = %d, 期望 %d", index.Len(), len(testArticles()))
+	}
+	if hits, _ := index.Search("晴", Options{}); len(hits) != 0 {
+		t.Errorf("旧版本仍能检索到: %v", hitIDs(hits))
+	}
+	if hits, _ := index.Search("有雪", Options{}); !equalIDs(hitIDs(hits), []int64{3}) {
+		t.Errorf("新版本检索结果 = %v, 期望 [3]", hitIDs(hits))
+	}
+}
+
+func TestOpenReplaysJournal(t *testing.T) {
+	articles := testArticles()
+	cases := []struct {
+		name    string
+		tail    string // 追加在日志末尾的内容
+		wantErr bool
+	}{
+		{"完整日志", "", false},
+		{"最后一行不完整", `{"id":99,"title":"写入中`, false},
+		{"中间一行损坏", "不是JSON\n" + `{"id":99,"title":"完整的行"}` + "\n", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "index.gob")
+
+			// 前三篇写入快照，后三篇只在日志中
+			index := NewIndex(path)
+			for _, article := range articles[:3] {
+				index.Add(article)
+			}
+			if err := index.Save(); err != nil {
+				t.Fatalf("写入索引失败: %v", err)
+			}
+			for _, article := range articles[3:] {
+				index.Add(article)
+			}
+			if err := index.Journal(articles[3:]); err != nil {
+				t.Fatalf("写入日志失败: %v", err)
+			}
+			if tc.tail != "" {
+				file, err := os.OpenFile(index.journalPath(), os.O_WRONLY|os.O_APPEND, 0644)
+				if err != nil {
+					t.Fatalf("打开日志失败: %v", err)
+				}
+				file.WriteString(tc.tail)
+				file.Close()
+			}
+
+			reopened, err := Open(path)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("日志中间一行损坏时没有返回错误")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("打开索引失败: %v", err)
+			}
+			if reopened.Len() != len(articles) || reopened.Journaled() != len(articles)-3 {
+				t.Errorf("文档数 = %d, 日志文章数 = %d, 期望 %d, %d", reopened.Len(), reopened.Journaled(), len(articles), len(articles)-3)
+			}
+			if hits, _ := reopened.Search("经济", Options{}); !equalIDs(hitIDs(hits), []int64{1, 4, 2, 5, 6}) {
+				t.Errorf("重新打开后检索结果 = %v", hitIDs(hits))
+			}
+
+			// 写入快照后日志被清空
+			if err := reopened.Save(); err != nil {
+				t.Fatalf("写入索引失败: %v", err)
+			}
+			if _, err := os.Stat(reopened.journalPath()); !os.IsNotExist(err) {
+				t.Errorf("写入快照后日志仍然存在")
+			}
+			again, err := Open(path)
+			if err != nil || again.Len() != len(articles) || again.Journaled() != 0 {
+				t.Errorf("再次打开 = %v, 文档数 %d, 日志文章数 %d", err, again.Len(), again.Journaled())
+			}
+		})
+	}
+}
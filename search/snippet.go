@@ -0,0 +1,97 @@
+package search
+
+import (
+	"strings"
+)
+
+// 高亮标记
+const (
+	HighlightStart = "【"
+	HighlightEnd   = "】"
+)
+
+// Snippet 截取第一个命中词组附近的正文片段，并高亮全部命中词组
+// radius 为命中位置前后保留的字符数
+func Snippet(content string, terms []string, radius int) string {
+	runes := []rune(content)
+	lower := []rune(strings.ToLower(content))
+
+	first := -1
+	for _, term := range terms {
+		if pos := runeIndex(lower, []rune(strings.ToLower(term))); pos >= 0 && (first < 0 || pos < first) {
+			first = pos
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+
+	start := first - radius
+	if start < 0 {
+		start = 0
+	}
+	end := first + radius*2
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	snippet := highlight(runes[start:end], lower[start:end], terms)
+	snippet = strings.Join(strings.Fields(snippet), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// highlight 在片段中为命中词组加上高亮标记
+func highlight(runes, lower []rune, terms []string) string {
+	marked := make([]bool, len(runes))
+	for _, term := range terms {
+		needle := []rune(strings.ToLower(term))
+		for offset := 0; offset+len(needle) <= len(lower); {
+			pos := runeIndex(lower[offset:], needle)
+			if pos < 0 {
+				break
+			}
+			for i := offset + pos; i < offset+pos+len(needle); i++ {
+				marked[i] = true
+			}
+			offset += pos + len(needle)
+		}
+	}
+
+	var builder strings.Builder
+	for i, r := range runes {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			builder.WriteString(HighlightStart)
+		}
+		builder.WriteRune(r)
+		if marked[i] && (i == len(runes)-1 || !marked[i+1]) {
+			builder.WriteString(HighlightEnd)
+		}
+	}
+	return builder.String()
+}
+
+// runeIndex 在rune切片中查找子串位置
+func runeIndex(haystack, needle []rune) int {
+	if len(needle) == 0 {
+		return -1
+	}
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
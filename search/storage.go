@@ -0,0 +1,78 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// checkpointEvery 日志中累积多少篇文章后重写一次索引文件，控制打开时重放日志的耗时
+const checkpointEvery = 50000
+
+// IndexStorage 以存储的形式接入抓取流程，在SaveBatch时增量更新索引
+// 新增的文章追加到索引日志，Close和检查点时才重写整个索引文件
+type IndexStorage struct {
+	path  string
+	index *Index
+}
+
+// NewIndexStorage 创建索引存储
+func NewIndexStorage(path string) *IndexStorage {
+	return &IndexStorage{path: path}
+}
+
+// Init 加载已有索引
+func (s *IndexStorage) Init() error {
+	index, err := Open(s.path)
+	if err != nil {
+		return err
+	}
+	s.index = index
+	return nil
+}
+
+// Save 索引单篇文章
+func (s *IndexStorage) Save(article *models.Article) error {
+	return s.SaveBatch([]*models.Article{article})
+}
+
+// SaveBatch 批量索引文章
+func (s *IndexStorage) SaveBatch(articles []*models.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	for _, article := range articles {
+		s.index.Add(article)
+	}
+	if err := s.index.Journal(articles); err != nil {
+		return err
+	}
+
+	if s.index.Journaled() >= checkpointEvery {
+		if err := s.index.Save(); err != nil {
+			return fmt.Errorf("写入索引失败: %v", err)
+		}
+	}
+	return nil
+}
+
+// Has 检查规范ID是否已在索引中
+func (s *IndexStorage) Has(ids []int64) (map[int64]bool, error) {
+	return s.index.Has(ids), nil
+}
+
+// Close 日志中有文章时重写索引文件
+func (s *IndexStorage) Close() error {
+	if s.index == nil || s.index.Journaled() == 0 {
+		return nil
+	}
+	if err := s.index.Save(); err != nil {
+		return fmt.Errorf("写入索引失败: %v", err)
+	}
+	return nil
+}
+
+// GetStorageType 获取存储类型
+func (s *IndexStorage) GetStorageType() string {
+	return "search"
+}
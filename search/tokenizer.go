@@ -0,0 +1,74 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize 将文本切分为索引词
+// 连续的汉字切分为单字和重叠的二元组，字母数字串转为小写整词，其余字符作为分隔符
+func Tokenize(text string) []string {
+	return tokenize(text, true)
+}
+
+// queryTokens 将查询词组切分为检索词
+// 连续的汉字只取二元组，单独一个汉字时取单字，倒排表较短
+func queryTokens(text string) []string {
+	return tokenize(text, false)
+}
+
+// tokenize 切分文本，unigrams为true时为每个汉字输出单字
+func tokenize(text string, unigrams bool) []string {
+	var (
+		tokens []string
+		han    []rune
+		word   []rune
+	)
+
+	flushHan := func() {
+		if unigrams || len(han) == 1 {
+			for _, r := range han {
+				tokens = append(tokens, string(r))
+			}
+		}
+		for i := 0; i+1 < len(han); i++ {
+			tokens = append(tokens, string(han[i:i+2]))
+		}
+		han = han[:0]
+	}
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, strings.ToLower(string(word)))
+		}
+		word = word[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushHan()
+			flushWord()
+		}
+	}
+	flushHan()
+	flushWord()
+
+	return tokens
+}
+
+// queryTerms 将查询拆分为需要全部命中的词组（按空白分隔）
+func queryTerms(query string) []string {
+	var terms []string
+	for _, term := range strings.Fields(query) {
+		if len(queryTokens(term)) > 0 {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
@@ -9,41 +9,168 @@ USE people_daily;
 
 -- 创建文章表
 CREATE TABLE IF NOT EXISTS articles (
-    id BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT 'id - 文章ID',
-    url VARCHAR(1000) NOT NULL COMMENT 'url - 原始链接',
+    id BIGINT PRIMARY KEY COMMENT 'id - 规范ID，站点文档ID或发布日期、版次、标题的哈希',
     title VARCHAR(500) NOT NULL COMMENT 'title - 标题',
+    url VARCHAR(1000) NOT NULL COMMENT 'url - 原始链接',
+    permalink VARCHAR(1000) COMMENT 'permalink - 文章页面声明的固定链接',
     subtitle VARCHAR(500) COMMENT 'subtitle - 记者名字/小标题，可能是空的',
     raw TEXT COMMENT 'raw - 特征的内容的全部',
-    publish_date DATETIME COMMENT 'publish_date - 来自特征的内容的时间，如 2025年8月30日',
     edition VARCHAR(50) COMMENT 'edition - 第几版，如第1版',
     type VARCHAR(100) COMMENT 'type - 类型如要闻，可能是空的',
     content LONGTEXT COMMENT 'content - 文章内容',
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间（系统字段）',
+    content_markdown LONGTEXT COMMENT 'content_markdown - 正文Markdown，保留段落、标题、强调、列表和表格',
+    content_hash CHAR(64) COMMENT '正文精确哈希',
+    simhash BIGINT UNSIGNED COMMENT '正文SimHash，用于近似重复检测',
+    duplicate_of BIGINT COMMENT '重复文章的规范副本ID',
+    reporters VARCHAR(500) COMMENT 'reporters - 记者，逗号分隔',
+    agency VARCHAR(100) COMMENT 'agency - 发稿机构，如本报、新华社',
+    dateline_location VARCHAR(100) COMMENT 'dateline_location - 电头地点',
+    dateline_date DATE COMMENT 'dateline_date - 电头日期',
+    column_name VARCHAR(100) COMMENT 'column - 栏目，如人民时评、今日谈',
+    ordinal INT COMMENT '文章在版面中的顺序，从1开始，按版面抓取时才有',
+    page_coords VARCHAR(255) COMMENT '文章在版面图上的热区坐标',
+    summary TEXT COMMENT 'summary - 摘要',
+    publish_date DATETIME NOT NULL COMMENT 'publish_date - 来自特征的内容的时间，如 2025年8月30日',
+    author VARCHAR(200) COMMENT '作者',
+    source VARCHAR(200) COMMENT '来源',
+    keywords VARCHAR(500) COMMENT 'keywords - 关键词，逗号分隔',
+    category VARCHAR(100) COMMENT '分类',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '抓取时间，重新抓取时标题或正文变化才更新，归档的历史版本以此为抓取时间',
     
     -- 索引
-    UNIQUE KEY uk_url (url) COMMENT 'URL唯一索引',
+    INDEX idx_url (url(255)) COMMENT 'URL索引，同一篇文章可能有不同的检索URL',
     INDEX idx_publish_date (publish_date) COMMENT '发布日期索引',
-    INDEX idx_title (title) COMMENT '标题索引',
+    INDEX idx_author (author) COMMENT '作者索引',
+    INDEX idx_category (category) COMMENT '分类索引',
     INDEX idx_edition (edition) COMMENT '版次索引',
     INDEX idx_type (type) COMMENT '类型索引',
-    INDEX idx_created_at (created_at) COMMENT '创建时间索引'
+    INDEX idx_content_hash (content_hash) COMMENT '正文哈希索引',
+    INDEX idx_agency (agency) COMMENT '发稿机构索引',
+    INDEX idx_dateline_location (dateline_location) COMMENT '电头地点索引',
+    INDEX idx_column_name (column_name) COMMENT '栏目索引'
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='人民日报文章表';
 
--- 创建统计表（可选）
+-- 创建统计表（每次抓取运行一条记录）
 CREATE TABLE IF NOT EXISTS crawl_stats (
     id INT AUTO_INCREMENT PRIMARY KEY,
+    run_id VARCHAR(64) NOT NULL COMMENT '运行ID',
+    config_hash VARCHAR(64) COMMENT '配置指纹',
     crawl_date DATE NOT NULL COMMENT '爬取日期',
+    range_start DATE COMMENT '抓取范围开始日期',
+    range_end DATE COMMENT '抓取范围结束日期',
+    status VARCHAR(20) COMMENT '运行状态 completed/interrupted',
+    total_tasks INT DEFAULT 0 COMMENT '任务数量',
     articles_count INT DEFAULT 0 COMMENT '文章数量',
     success_count INT DEFAULT 0 COMMENT '成功数量',
     failed_count INT DEFAULT 0 COMMENT '失败数量',
+    quarantined_count INT DEFAULT 0 COMMENT '隔离文章数量',
+    skipped_count INT DEFAULT 0 COMMENT '已存储而跳过的文章数量',
     start_time DATETIME COMMENT '开始时间',
     end_time DATETIME COMMENT '结束时间',
     duration_seconds INT COMMENT '耗时(秒)',
+    reported_totals TEXT COMMENT '网站报告的各时间段文章总数(JSON)',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     
-    UNIQUE KEY uk_crawl_date (crawl_date) COMMENT '爬取日期唯一索引'
+    UNIQUE KEY uk_run_id (run_id) COMMENT '运行ID唯一索引',
+    INDEX idx_crawl_date (crawl_date) COMMENT '爬取日期索引'
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='爬取统计表';
 
+-- 创建文章历史版本表（重新抓取时标题或正文变化，旧版本归档到此表）
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    article_id BIGINT NOT NULL COMMENT '所属文章的规范ID',
+    url VARCHAR(1000) NOT NULL COMMENT '原始链接',
+    title VARCHAR(500) COMMENT '标题',
+    subtitle VARCHAR(500) COMMENT '记者名字/小标题',
+    raw TEXT COMMENT '特征的内容的全部',
+    publish_date DATETIME COMMENT '发布日期',
+    edition VARCHAR(50) COMMENT '版次',
+    type VARCHAR(100) COMMENT '类型',
+    content LONGTEXT COMMENT '文章内容',
+    content_hash CHAR(64) COMMENT '正文精确哈希',
+    crawled_at TIMESTAMP NULL COMMENT '该版本的抓取时间',
+    archived_at DATETIME NOT NULL COMMENT '被新版本替换的时间',
+
+    INDEX idx_article_id (article_id) COMMENT '文章ID索引',
+    INDEX idx_archived_at (archived_at) COMMENT '归档时间索引'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文章历史版本表';
+
+-- 创建文章图片表（图片按出现顺序存储，开启assets时记录本地路径和内容哈希）
+CREATE TABLE IF NOT EXISTS article_images (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    article_id BIGINT NOT NULL COMMENT '所属文章的规范ID',
+    article_url VARCHAR(1000) NOT NULL COMMENT '所属文章URL',
+    position INT NOT NULL COMMENT '图片在文章中的顺序，从0开始',
+    url VARCHAR(1000) NOT NULL COMMENT '图片地址',
+    caption VARCHAR(1000) COMMENT '图注',
+    local_path VARCHAR(500) COMMENT '本地路径，未下载时为空',
+    sha256 CHAR(64) COMMENT '图片内容SHA-256',
+    size BIGINT COMMENT '图片字节数',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间（系统字段）',
+
+    INDEX idx_article_id (article_id) COMMENT '文章ID索引',
+    INDEX idx_sha256 (sha256) COMMENT '图片哈希索引'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文章图片表';
+
+-- 文章表格表
+CREATE TABLE IF NOT EXISTS article_tables (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    article_id BIGINT NOT NULL COMMENT '所属文章的规范ID',
+    article_url VARCHAR(1000) NOT NULL COMMENT '所属文章URL',
+    position INT NOT NULL COMMENT '表格在文章中的顺序，从0开始',
+    caption VARCHAR(500) COMMENT '表题',
+    column_count INT NOT NULL COMMENT '列数',
+    row_count INT NOT NULL COMMENT '数据行数，不含表头',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间（系统字段）',
+
+    INDEX idx_article_id (article_id) COMMENT '文章ID索引'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文章表格表';
+
+-- 文章表格单元格表
+CREATE TABLE IF NOT EXISTS article_table_cells (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    table_id BIGINT NOT NULL COMMENT '所属表格ID',
+    row_index INT NOT NULL COMMENT '行号，表头为0，数据行从1开始',
+    column_index INT NOT NULL COMMENT '列号，从0开始',
+    is_header TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否表头',
+    value TEXT COMMENT '单元格文字',
+
+    INDEX idx_table_cell (table_id, row_index, column_index) COMMENT '表格单元格索引'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='文章表格单元格表';
+
+-- 报纸表（按电子版抓取时每天一条）
+CREATE TABLE IF NOT EXISTS issues (
+    issue_date DATE PRIMARY KEY COMMENT '出版日期',
+    total_editions INT NOT NULL COMMENT '当天的版数',
+    crawled_at DATETIME COMMENT '抓取时间',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间（系统字段）'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='报纸表';
+
+-- 版面表
+CREATE TABLE IF NOT EXISTS pages (
+    issue_date DATE NOT NULL COMMENT '出版日期',
+    edition INT NOT NULL COMMENT '版次，从1开始',
+    name VARCHAR(100) COMMENT '版面名称，如要闻、评论',
+    url VARCHAR(1000) COMMENT '版面地址',
+    pdf_url VARCHAR(1000) COMMENT '版面PDF地址',
+
+    PRIMARY KEY (issue_date, edition)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='版面表';
+
+-- 版面文章表（文章在版面中的顺序，通过article_id关联articles表）
+CREATE TABLE IF NOT EXISTS page_articles (
+    issue_date DATE NOT NULL COMMENT '出版日期',
+    edition INT NOT NULL COMMENT '版次',
+    ordinal INT NOT NULL COMMENT '在版面中的顺序，从1开始',
+    article_id BIGINT COMMENT '文章的规范ID，版面链接中没有文档ID时为空',
+    article_url VARCHAR(1000) NOT NULL COMMENT '文章URL',
+    title VARCHAR(500) COMMENT '版面中列出的标题',
+    coords VARCHAR(255) COMMENT '版面图热区坐标',
+
+    PRIMARY KEY (issue_date, edition, ordinal),
+    INDEX idx_article_id (article_id) COMMENT '文章ID索引'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='版面文章表';
+
 -- 插入示例查询
 -- 按年份统计文章数量
 -- SELECT YEAR(publish_date) as year, COUNT(*) as count 
@@ -2,13 +2,17 @@ package storage
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/Lan-ce-lot/data-people/dedupe"
 	"github.com/Lan-ce-lot/data-people/models"
 )
 
@@ -19,6 +23,12 @@ type CSVStorage struct {
 	mu         sync.Mutex
 	files      map[string]*os.File
 	writers    map[string]*csv.Writer
+
+	stored       map[int64]storedArticle // 已存储文章（按规范ID），用于重新抓取时的变化检测
+	loaded       map[string]bool         // 已加载到stored的月份，只读时不加载
+	allLoaded    bool                    // 全部月份已加载
+	issueOffsets map[string]int64        // 版面文件中各日期最后一条记录的位置，nil表示尚未读取
+	dedupeIndex  *dedupe.Index           // 保存时去重的指纹索引，nil表示不去重
 }
 
 // NewCSVStorage 创建CSV存储实例
@@ -28,10 +38,13 @@ func NewCSVStorage(outputDir, filePrefix string) *CSVStorage {
 		filePrefix: filePrefix,
 		files:      make(map[string]*os.File),
 		writers:    make(map[string]*csv.Writer),
+		stored:     make(map[int64]storedArticle),
+		loaded:     make(map[string]bool),
 	}
 }
 
 // Init 初始化CSV存储
+// 已有文章的位置和指纹在保存或检查时按月份加载，只读的命令不需要读取全部文章
 func (c *CSVStorage) Init() error {
 	// 创建输出目录
 	if err := os.MkdirAll(c.outputDir, 0755); err != nil {
@@ -40,6 +53,62 @@ func (c *CSVStorage) Init() error {
 	return nil
 }
 
+// loadMonths 加载指定月份已有文章的位置和指纹，已加载的月份跳过
+// 需要持有c.mu，且须在写入该月份之前调用
+func (c *CSVStorage) loadMonths(monthKeys []string) error {
+	for _, monthKey := range monthKeys {
+		if c.loaded[monthKey] {
+			continue
+		}
+
+		path := filepath.Join(c.outputDir, fmt.Sprintf("%s_%s.csv", c.filePrefix, monthKey))
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			c.loaded[monthKey] = true
+			continue
+		}
+		articles, err := c.readMonth(monthKey)
+		if err != nil {
+			return fmt.Errorf("加载已有文章失败: %v", err)
+		}
+		for _, article := range articles {
+			dedupe.Fingerprint(article)
+			c.stored[article.ID] = storedArticle{
+				monthKey:    monthKey,
+				contentHash: article.ContentHash,
+				title:       article.Title,
+				createdAt:   article.CreatedAt,
+			}
+			if c.dedupeIndex != nil {
+				c.dedupeIndex.Add(article.ID, article.ContentHash, article.SimHash)
+			}
+		}
+		c.loaded[monthKey] = true
+	}
+	return nil
+}
+
+// loadAll 加载全部月份，按ID检查和跨月份去重时需要
+func (c *CSVStorage) loadAll() error {
+	if c.allLoaded {
+		return nil
+	}
+	monthKeys, err := c.listMonthKeys()
+	if err != nil {
+		return err
+	}
+	if err := c.loadMonths(monthKeys); err != nil {
+		return err
+	}
+	c.allLoaded = true
+	return nil
+}
+
+// EnableDedupe 开启保存时去重，与已有文章海明距离不超过maxDistance的文章不再写入
+// 需要在第一次保存或检查之前调用
+func (c *CSVStorage) EnableDedupe(maxDistance int) {
+	c.dedupeIndex = dedupe.NewIndex(maxDistance)
+}
+
 // Save 保存单个文章
 func (c *CSVStorage) Save(article *models.Article) error {
 	return c.SaveBatch([]*models.Article{article})
@@ -50,6 +119,31 @@ func (c *CSVStorage) SaveBatch(articles []*models.Article) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	// 未经处理流程的文章在这里补充规范ID
+	var monthKeys []string
+	for _, article := range articles {
+		article.EnsureID()
+		monthKeys = append(monthKeys, article.PublishDate.Format("200601"))
+	}
+
+	// 去重需要与全部已有文章比较，否则只加载涉及的月份
+	if c.dedupeIndex != nil {
+		if err := c.loadAll(); err != nil {
+			return err
+		}
+	} else if err := c.loadMonths(monthKeys); err != nil {
+		return err
+	}
+
+	// 计算指纹，开启去重时跳过重复文章
+	articles = suppressDuplicates(c.dedupeIndex, articles, c.GetStorageType())
+
+	// 内容未变化的文章不重复写入，变化的文章归档旧版本
+	articles, err := c.detectRevisions(articles)
+	if err != nil {
+		return err
+	}
+
 	// 按月份分组文章
 	monthlyGroups := make(map[string][]*models.Article)
 	for _, article := range articles {
@@ -67,6 +161,46 @@ func (c *CSVStorage) SaveBatch(articles []*models.Article) error {
 	return nil
 }
 
+// Has 检查规范ID是否已存储，第一次调用时加载全部月份
+func (c *CSVStorage) Has(ids []int64) (map[int64]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.loadAll(); err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]bool)
+	for _, id := range ids {
+		if _, ok := c.stored[id]; ok {
+			found[id] = true
+		}
+	}
+	return found, nil
+}
+
+// HasInRange 只加载发布日期范围涉及的月份并检查规范ID是否已存储
+func (c *CSVStorage) HasInRange(ids []int64, from, to time.Time) (map[int64]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var monthKeys []string
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()); !month.After(to); month = month.AddDate(0, 1, 0) {
+		monthKeys = append(monthKeys, month.Format("200601"))
+	}
+	if err := c.loadMonths(monthKeys); err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]bool)
+	for _, id := range ids {
+		if _, ok := c.stored[id]; ok {
+			found[id] = true
+		}
+	}
+	return found, nil
+}
+
 // writeToFile 写入指定月份的文件
 func (c *CSVStorage) writeToFile(monthKey string, articles []*models.Article) error {
 	writer, err := c.getWriter(monthKey)
@@ -75,20 +209,7 @@ func (c *CSVStorage) writeToFile(monthKey string, articles []*models.Article) er
 	}
 
 	for _, article := range articles {
-		record := []string{
-			strconv.Itoa(article.ID),
-			article.URL,
-			article.Title,
-			article.Subtitle,
-			c.escapeCSVField(article.Raw),
-			article.PublishDate.Format("2006-01-02 15:04:05"),
-			article.Edition,
-			article.Type,
-			c.escapeCSVField(article.Content),
-			article.CreatedAt.Format("2006-01-02 15:04:05"),
-		}
-
-		if err := writer.Write(record); err != nil {
+		if err := writer.Write(c.articleToRecord(article)); err != nil {
 			return fmt.Errorf("写入CSV记录失败: %v", err)
 		}
 	}
@@ -102,6 +223,79 @@ func (c *CSVStorage) writeToFile(monthKey string, articles []*models.Article) er
 	return nil
 }
 
+// csvHeader CSV文件头部
+var csvHeader = []string{
+	"id", "url", "title", "subtitle", "raw",
+	"publish_date", "edition", "type", "content", "created_at",
+	"content_hash", "simhash", "keywords", "summary",
+	"reporters", "agency", "dateline_location", "dateline_date",
+	"column", "images", "content_markdown", "tables",
+	"ordinal", "page_coords", "permalink",
+}
+
+// articleToRecord 将文章转换为CSV记录，字段顺序与csvHeader一致
+func (c *CSVStorage) articleToRecord(article *models.Article) []string {
+	return []string{
+		strconv.FormatInt(article.ID, 10),
+		article.URL,
+		article.Title,
+		article.Subtitle,
+		c.escapeCSVField(article.Raw),
+		article.PublishDate.Format("2006-01-02 15:04:05"),
+		article.Edition,
+		article.Type,
+		c.escapeCSVField(article.Content),
+		article.CreatedAt.Format("2006-01-02 15:04:05"),
+		article.ContentHash,
+		formatSimHash(article.SimHash),
+		article.Keywords,
+		c.escapeCSVField(article.Summary),
+		strings.Join(article.Reporters, ","),
+		article.Agency,
+		article.DatelineLocation,
+		formatCSVDate(article.DatelineDate),
+		article.Column,
+		formatCSVJSON(article.Images),
+		article.ContentMarkdown, // Markdown依赖换行，不做转义，由csv包加引号
+		formatCSVJSON(article.Tables),
+		formatCSVOrdinal(article.Ordinal),
+		article.PageCoords,
+		article.Permalink,
+	}
+}
+
+// formatCSVOrdinal 格式化版面顺序，没有顺序时写为空
+func formatCSVOrdinal(ordinal int) string {
+	if ordinal == 0 {
+		return ""
+	}
+	return strconv.Itoa(ordinal)
+}
+
+// formatCSVDate 格式化日期，零值写为空
+func formatCSVDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+// formatCSVJSON 图片、表格等列表写为JSON数组，空列表写为空
+func formatCSVJSON(items interface{}) string {
+	data, err := json.Marshal(items)
+	if err != nil || string(data) == "null" || string(data) == "[]" {
+		return ""
+	}
+	return string(data)
+}
+
+// parseCSVJSON 解析JSON数组形式的列表，空值或格式错误时保持原值
+func parseCSVJSON(value string, items interface{}) {
+	if value != "" {
+		_ = json.Unmarshal([]byte(value), items)
+	}
+}
+
 // getWriter 获取指定月份的CSV写入器
 func (c *CSVStorage) getWriter(monthKey string) (*csv.Writer, error) {
 	if writer, exists := c.writers[monthKey]; exists {
@@ -116,6 +310,8 @@ func (c *CSVStorage) getWriter(monthKey string) (*csv.Writer, error) {
 	var writeHeader bool
 	if _, err := os.Stat(filepath); os.IsNotExist(err) {
 		writeHeader = true
+	} else if err := c.upgradeHeader(monthKey, filepath); err != nil {
+		return nil, err
 	}
 
 	file, err := os.OpenFile(filepath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
@@ -127,11 +323,7 @@ func (c *CSVStorage) getWriter(monthKey string) (*csv.Writer, error) {
 
 	// 写入头部（如果是新文件）
 	if writeHeader {
-		header := []string{
-			"id", "url", "title", "subtitle", "raw",
-			"publish_date", "edition", "type", "content", "created_at",
-		}
-		if err := writer.Write(header); err != nil {
+		if err := writer.Write(csvHeader); err != nil {
 			file.Close()
 			return nil, fmt.Errorf("写入CSV头部失败: %v", err)
 		}
@@ -144,6 +336,97 @@ func (c *CSVStorage) getWriter(monthKey string) (*csv.Writer, error) {
 	return writer, nil
 }
 
+// upgradeHeader 旧文件的头部与当前字段不一致时，按当前字段重写整个文件，保证追加的记录与头部对齐
+func (c *CSVStorage) upgradeHeader(monthKey, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("打开CSV文件失败: %v", err)
+	}
+	header, err := csv.NewReader(file).Read()
+	file.Close()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取CSV头部失败: %v", err)
+	}
+
+	if strings.Join(header, ",") == strings.Join(csvHeader, ",") {
+		return nil
+	}
+
+	articles, err := c.readMonth(monthKey)
+	if err != nil {
+		return err
+	}
+	return c.rewriteMonth(monthKey, articles)
+}
+
+// rewriteMonth 用给定文章重写月份文件（先写临时文件再替换）
+func (c *CSVStorage) rewriteMonth(monthKey string, articles []*models.Article) error {
+	path := filepath.Join(c.outputDir, fmt.Sprintf("%s_%s.csv", c.filePrefix, monthKey))
+	tmpPath := path + ".tmp"
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("创建临时CSV文件失败: %v", err)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Write(csvHeader)
+	for _, article := range articles {
+		writer.Write(c.articleToRecord(article))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入临时CSV文件失败: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("关闭临时CSV文件失败: %v", err)
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// UpdateDerived 按规范ID更新文章的关键词、摘要和栏目，按月份重写受影响的文件
+func (c *CSVStorage) UpdateDerived(articles []*models.Article) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	monthlyUpdates := make(map[string]map[int64]*models.Article)
+	for _, article := range articles {
+		monthKey := article.PublishDate.Format("200601")
+		if monthlyUpdates[monthKey] == nil {
+			monthlyUpdates[monthKey] = make(map[int64]*models.Article)
+		}
+		monthlyUpdates[monthKey][article.ID] = article
+	}
+
+	for monthKey, updates := range monthlyUpdates {
+		c.closeMonth(monthKey)
+
+		monthArticles, err := c.readMonth(monthKey)
+		if err != nil {
+			return err
+		}
+		for _, article := range monthArticles {
+			if update, exists := updates[article.ID]; exists {
+				article.Keywords = update.Keywords
+				article.Summary = update.Summary
+				article.Column = update.Column
+			}
+		}
+		if err := c.rewriteMonth(monthKey, monthArticles); err != nil {
+			return fmt.Errorf("重写CSV文件失败 [%s]: %v", monthKey, err)
+		}
+	}
+
+	return nil
+}
+
 // escapeCSVField 转义CSV字段中的特殊字符
 func (c *CSVStorage) escapeCSVField(field string) string {
 	// 替换换行符为空格
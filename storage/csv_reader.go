@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// ForEach 按 (发布日期, 规范ID) 升序遍历满足条件的文章
+// 按月份文件依次读取，每个文件在内存中排序后再过滤
+func (c *CSVStorage) ForEach(filter ArticleFilter, fn func(article *models.Article) error) error {
+	monthKeys, err := c.listMonthKeys()
+	if err != nil {
+		return err
+	}
+
+	marks, err := c.loadDuplicateMarks()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, monthKey := range monthKeys {
+		if !monthInRange(monthKey, filter) {
+			continue
+		}
+
+		articles, err := c.readMonth(monthKey)
+		if err != nil {
+			return err
+		}
+		sortArticles(articles)
+
+		for _, article := range articles {
+			if !filter.Match(article) || !filter.After.IsAfter(article) {
+				continue
+			}
+			article.DuplicateOf = marks[article.ID]
+			if err := fn(article); err != nil {
+				return err
+			}
+			count++
+			if filter.Limit > 0 && count >= filter.Limit {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// listMonthKeys 列出输出目录中已有的月份文件（YYYYMM），按升序排列
+func (c *CSVStorage) listMonthKeys() ([]string, error) {
+	pattern := filepath.Join(c.outputDir, c.filePrefix+"_*.csv")
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("查找CSV文件失败: %v", err)
+	}
+
+	var monthKeys []string
+	prefix := c.filePrefix + "_"
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".csv")
+		monthKey := strings.TrimPrefix(name, prefix)
+		if _, err := strconv.Atoi(monthKey); err != nil {
+			continue
+		}
+		monthKeys = append(monthKeys, monthKey)
+	}
+	sort.Strings(monthKeys)
+
+	return monthKeys, nil
+}
+
+// monthInRange 判断月份文件是否可能包含过滤范围内的文章
+func monthInRange(monthKey string, filter ArticleFilter) bool {
+	if !filter.From.IsZero() && monthKey < filter.From.Format("200601") {
+		return false
+	}
+	if !filter.To.IsZero() && monthKey > filter.To.Format("200601") {
+		return false
+	}
+	if filter.After != nil && monthKey < filter.After.PublishDate.Format("200601") {
+		return false
+	}
+	return true
+}
+
+// readMonth 读取指定月份文件中的全部文章
+func (c *CSVStorage) readMonth(monthKey string) ([]*models.Article, error) {
+	filename := filepath.Join(c.outputDir, fmt.Sprintf("%s_%s.csv", c.filePrefix, monthKey))
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("打开CSV文件失败: %v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取CSV头部失败 [%s]: %v", filename, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+
+	var articles []*models.Article
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取CSV记录失败 [%s]: %v", filename, err)
+		}
+		articles = append(articles, recordToArticle(columns, record))
+	}
+
+	return articles, nil
+}
+
+// recordToArticle 按头部列名将CSV记录还原为文章，兼容列顺序不同的旧文件
+func recordToArticle(columns map[string]int, record []string) *models.Article {
+	field := func(name string) string {
+		if i, exists := columns[name]; exists && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	article := &models.Article{
+		URL:             field("url"),
+		Title:           field("title"),
+		Subtitle:        field("subtitle"),
+		Raw:             field("raw"),
+		Edition:         field("edition"),
+		Type:            field("type"),
+		Content:         field("content"),
+		Summary:         field("summary"),
+		Column:          field("column"),
+		ContentMarkdown: field("content_markdown"),
+		Keywords:        field("keywords"),
+		ContentHash:     field("content_hash"),
+		SimHash:         parseSimHash(field("simhash")),
+	}
+	article.Permalink = field("permalink")
+	// 早期文件中的ID都是0，按规范ID补充
+	article.ID, _ = strconv.ParseInt(field("id"), 10, 64)
+	article.EnsureID()
+	article.Ordinal, _ = strconv.Atoi(field("ordinal"))
+	article.PageCoords = field("page_coords")
+	article.PublishDate = parseCSVTime(field("publish_date"))
+	article.CreatedAt = parseCSVTime(field("created_at"))
+	article.Agency = field("agency")
+	article.DatelineLocation = field("dateline_location")
+	article.DatelineDate, _ = time.ParseInLocation("2006-01-02", field("dateline_date"), time.UTC)
+	if reporters := field("reporters"); reporters != "" {
+		article.Reporters = strings.Split(reporters, ",")
+	}
+	parseCSVJSON(field("images"), &article.Images)
+	parseCSVJSON(field("tables"), &article.Tables)
+
+	return article
+}
+
+// parseCSVTime 解析CSV中的时间字段，解析失败返回零值
+func parseCSVTime(value string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.UTC)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/Lan-ce-lot/data-people/dedupe"
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// DuplicateMarker 标记重复文章的接口
+type DuplicateMarker interface {
+	// MarkDuplicates 用新的标记替换全部重复标记，键为重复文章的规范ID，值为规范副本的规范ID
+	MarkDuplicates(marks map[int64]int64) error
+}
+
+// suppressDuplicates 为文章计算指纹，index不为nil时过滤掉与已有文章重复的文章
+func suppressDuplicates(index *dedupe.Index, articles []*models.Article, storageType string) []*models.Article {
+	kept := make([]*models.Article, 0, len(articles))
+	for _, article := range articles {
+		dedupe.Fingerprint(article)
+		if index == nil {
+			kept = append(kept, article)
+			continue
+		}
+
+		if match, distance, found := index.Find(article.ID, article.ContentHash, article.SimHash); found {
+			log.Printf("跳过重复文章 [%s] %s (与文章 %d 距离%d)", storageType, article.URL, match, distance)
+			continue
+		}
+		index.Add(article.ID, article.ContentHash, article.SimHash)
+		kept = append(kept, article)
+	}
+	return kept
+}
+
+// formatSimHash 将SimHash格式化为16位十六进制，0表示没有指纹
+func formatSimHash(simHash uint64) string {
+	if simHash == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%016x", simHash)
+}
+
+// parseSimHash 解析十六进制SimHash
+func parseSimHash(value string) uint64 {
+	simHash, _ := strconv.ParseUint(value, 16, 64)
+	return simHash
+}
+
+// duplicatesPath 重复标记文件路径
+func (c *CSVStorage) duplicatesPath() string {
+	return filepath.Join(c.outputDir, c.filePrefix+"_duplicates.csv")
+}
+
+// MarkDuplicates 将重复标记写入独立的标记文件，读取文章时合并
+func (c *CSVStorage) MarkDuplicates(marks map[int64]int64) error {
+	ids := make([]int64, 0, len(marks))
+	for id := range marks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	tmpPath := c.duplicatesPath() + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("创建重复标记文件失败: %v", err)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"id", "duplicate_of"})
+	for _, id := range ids {
+		writer.Write([]string{strconv.FormatInt(id, 10), strconv.FormatInt(marks[id], 10)})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入重复标记文件失败: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("关闭重复标记文件失败: %v", err)
+	}
+
+	return os.Rename(tmpPath, c.duplicatesPath())
+}
+
+// loadDuplicateMarks 读取重复标记文件，文件不存在时返回空表
+// 早期版本的标记文件以URL记录，读取时转换为规范ID并重写
+func (c *CSVStorage) loadDuplicateMarks() (map[int64]int64, error) {
+	marks := make(map[int64]int64)
+
+	file, err := os.Open(c.duplicatesPath())
+	if os.IsNotExist(err) {
+		return marks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开重复标记文件失败: %v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return marks, nil
+		}
+		return nil, fmt.Errorf("读取重复标记文件失败: %v", err)
+	}
+	legacy := len(header) > 0 && header[0] == "url"
+
+	urlMarks := make(map[string]string)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取重复标记文件失败: %v", err)
+		}
+		if len(record) < 2 {
+			continue
+		}
+		if legacy {
+			urlMarks[record[0]] = record[1]
+			continue
+		}
+		id, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("重复标记文件中的ID无效: %s", record[0])
+		}
+		duplicateOf, err := strconv.ParseInt(record[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("重复标记文件中的ID无效: %s", record[1])
+		}
+		marks[id] = duplicateOf
+	}
+
+	if legacy {
+		file.Close()
+		return c.convertDuplicateMarks(urlMarks)
+	}
+	return marks, nil
+}
+
+// convertDuplicateMarks 按已存储文章的URL将早期的重复标记转换为规范ID，并重写标记文件
+// 找不到对应文章的标记被丢弃，可重新运行 dedupe --mark 生成
+func (c *CSVStorage) convertDuplicateMarks(urlMarks map[string]string) (map[int64]int64, error) {
+	monthKeys, err := c.listMonthKeys()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int64)
+	for _, monthKey := range monthKeys {
+		articles, err := c.readMonth(monthKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, article := range articles {
+			ids[article.URL] = article.ID
+		}
+	}
+
+	marks := make(map[int64]int64)
+	for url, canonical := range urlMarks {
+		id, canonicalID := ids[url], ids[canonical]
+		if id == 0 || canonicalID == 0 {
+			continue
+		}
+		marks[id] = canonicalID
+	}
+	if err := c.MarkDuplicates(marks); err != nil {
+		return nil, err
+	}
+	log.Printf("✓ 重复标记已转换为规范ID: %d/%d 条", len(marks), len(urlMarks))
+	return marks, nil
+}
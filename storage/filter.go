@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// Match 判断文章是否满足过滤条件（不含游标和条数限制）
+func (f ArticleFilter) Match(article *models.Article) bool {
+	if !f.From.IsZero() && article.PublishDate.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !article.PublishDate.Before(f.To.AddDate(0, 0, 1)) {
+		return false
+	}
+	if f.Edition != "" && article.Edition != f.Edition {
+		return false
+	}
+	if f.Type != "" && article.Type != f.Type {
+		return false
+	}
+	if f.Column != "" && article.Column != f.Column {
+		return false
+	}
+	if f.Keyword != "" && !strings.Contains(article.Title, f.Keyword) {
+		return false
+	}
+	if f.ID != 0 && article.ID != f.ID {
+		return false
+	}
+	if f.URL != "" && article.URL != f.URL {
+		return false
+	}
+	return true
+}
+
+// IsAfter 判断文章是否排在游标之后
+func (c *Cursor) IsAfter(article *models.Article) bool {
+	if c == nil {
+		return true
+	}
+	if !article.PublishDate.Equal(c.PublishDate) {
+		return article.PublishDate.After(c.PublishDate)
+	}
+	return article.ID > c.ID
+}
+
+// sortArticles 按 (发布日期, 规范ID) 升序排序
+func sortArticles(articles []*models.Article) {
+	sort.SliceStable(articles, func(i, j int) bool {
+		if !articles[i].PublishDate.Equal(articles[j].PublishDate) {
+			return articles[i].PublishDate.Before(articles[j].PublishDate)
+		}
+		return articles[i].ID < articles[j].ID
+	})
+}
@@ -1,6 +1,10 @@
 package storage
 
-import "github.com/Lan-ce-lot/data-people/models"
+import (
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
 
 // Storage 存储接口
 type Storage interface {
@@ -13,9 +17,96 @@ type Storage interface {
 	// SaveBatch 批量保存文章
 	SaveBatch(articles []*models.Article) error
 
+	// Has 批量检查规范ID是否已存储，返回已存储的ID集合
+	Has(ids []int64) (map[int64]bool, error)
+
 	// Close 关闭存储连接
 	Close() error
 
 	// GetStorageType 获取存储类型
 	GetStorageType() string
 }
+
+// RangeChecker 可以只在发布日期范围内查找规范ID的存储
+// CSV存储按月份分文件，据此只加载范围涉及的月份，不必为一次查询读取全部数据
+type RangeChecker interface {
+	// HasInRange 与Has相同，但只保证找到发布日期在[from, to]内的文章
+	HasInRange(ids []int64, from, to time.Time) (map[int64]bool, error)
+}
+
+// RunStore 抓取运行记录存储接口
+type RunStore interface {
+	// SaveRun 保存一次抓取运行记录（按运行ID覆盖）
+	SaveRun(run *models.CrawlRun) error
+
+	// ListRuns 按开始时间倒序列出最近的运行记录，limit<=0表示不限制
+	ListRuns(limit int) ([]*models.CrawlRun, error)
+
+	// GetRun 根据运行ID获取运行记录
+	GetRun(id string) (*models.CrawlRun, error)
+}
+
+// RevisionStore 文章历史版本存储接口
+type RevisionStore interface {
+	// GetRevisions 按归档时间升序返回规范ID对应文章的历史版本，不含当前版本
+	GetRevisions(id int64) ([]*models.Revision, error)
+}
+
+// ImageStore 文章图片存储接口，用于图片不随文章一起读取的存储
+type ImageStore interface {
+	// GetImages 按出现顺序返回规范ID对应文章的图片
+	GetImages(id int64) ([]models.ArticleImage, error)
+}
+
+// TableStore 文章表格存储接口，用于表格不随文章一起读取的存储
+type TableStore interface {
+	// GetTables 按出现顺序返回规范ID对应文章的表格
+	GetTables(id int64) ([]models.ArticleTable, error)
+}
+
+// IssueStore 报纸版面存储接口，用于按日期还原整期报纸
+type IssueStore interface {
+	// SaveIssue 保存一期报纸的版面和版面中的文章顺序（按日期覆盖）
+	SaveIssue(issue *models.Issue) error
+
+	// GetIssue 获取指定日期的报纸
+	GetIssue(date time.Time) (*models.Issue, error)
+}
+
+// ArticleUpdater 更新已存储文章派生字段的接口，用于回填
+type ArticleUpdater interface {
+	// UpdateDerived 按规范ID更新文章的关键词、摘要和栏目，其余字段不变
+	UpdateDerived(articles []*models.Article) error
+}
+
+// ArticleFilter 文章查询条件，零值字段表示不限制
+type ArticleFilter struct {
+	From    time.Time // 发布日期下限（含）
+	To      time.Time // 发布日期上限（含当天）
+	Edition string    // 版次，如第1版
+	Type    string    // 类型，如要闻
+	Column  string    // 栏目，如人民时评
+	Keyword string    // 标题关键词
+	ID      int64     // 精确匹配规范ID
+	URL     string    // 精确匹配URL
+	After   *Cursor   // 从该位置之后开始（不含）
+	Limit   int       // 最多返回条数，<=0表示不限制
+}
+
+// Cursor 分页游标，文章按 (发布日期, 规范ID) 升序排列
+type Cursor struct {
+	PublishDate time.Time
+	ID          int64
+}
+
+// Reader 已存储文章的读取接口
+type Reader interface {
+	// ForEach 按 (发布日期, 规范ID) 升序遍历满足条件的文章，fn返回错误时停止遍历并返回该错误
+	ForEach(filter ArticleFilter, fn func(article *models.Article) error) error
+}
+
+// StatsProvider 语料统计接口
+type StatsProvider interface {
+	// GetStats 统计满足过滤条件的文章（忽略游标和条数限制）
+	GetStats(filter ArticleFilter) (*models.CorpusStats, error)
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// issuesPath 版面文件路径，每行一期报纸
+func (c *CSVStorage) issuesPath() string {
+	return filepath.Join(c.outputDir, c.filePrefix+"_issues.jsonl")
+}
+
+// SaveIssue 追加写入一期报纸，相同日期的后一条记录覆盖前一条
+func (c *CSVStorage) SaveIssue(issue *models.Issue) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	file, err := os.OpenFile(c.issuesPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("打开版面文件失败: %v", err)
+	}
+	defer file.Close()
+
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("打开版面文件失败: %v", err)
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(issue); err != nil {
+		return fmt.Errorf("写入版面文件失败: %v", err)
+	}
+	if c.issueOffsets != nil {
+		c.issueOffsets[issue.Date.Format("2006-01-02")] = offset
+	}
+	return nil
+}
+
+// GetIssue 按日期索引读取指定日期最后保存的报纸
+func (c *CSVStorage) GetIssue(date time.Time) (*models.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.loadIssueOffsets(); err != nil {
+		return nil, err
+	}
+	offset, exists := c.issueOffsets[date.Format("2006-01-02")]
+	if !exists {
+		return nil, fmt.Errorf("没有 %s 的版面记录", date.Format("2006-01-02"))
+	}
+
+	file, err := os.Open(c.issuesPath())
+	if err != nil {
+		return nil, fmt.Errorf("打开版面文件失败: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("读取版面文件失败: %v", err)
+	}
+	var issue models.Issue
+	if err := json.NewDecoder(file).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("解析版面文件失败: %v", err)
+	}
+	return &issue, nil
+}
+
+// loadIssueOffsets 第一次读取时扫描版面文件，建立日期到最后一条记录位置的索引
+// 需要持有c.mu，之后由SaveIssue维护
+func (c *CSVStorage) loadIssueOffsets() error {
+	if c.issueOffsets != nil {
+		return nil
+	}
+
+	offsets := make(map[string]int64)
+	file, err := os.Open(c.issuesPath())
+	if os.IsNotExist(err) {
+		c.issueOffsets = offsets
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("打开版面文件失败: %v", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, 1024*1024)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var issue struct {
+				Date time.Time `json:"date"`
+			}
+			if err := json.Unmarshal(line, &issue); err != nil {
+				return fmt.Errorf("解析版面文件失败: %v", err)
+			}
+			offsets[issue.Date.Format("2006-01-02")] = offset
+		}
+		offset += int64(len(line))
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("读取版面文件失败: %v", err)
+		}
+	}
+
+	c.issueOffsets = offsets
+	return nil
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Lan-ce-lot/data-people/dedupe"
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// MergeResult 合并一个分片的结果
+type MergeResult struct {
+	Articles  int // 分片中的文章数
+	Added     int // 新增文章数
+	Revised   int // 内容不同且抓取时间更晚、按修订写入的文章数
+	Unchanged int // 已存在且内容相同的文章数
+	Older     int // 内容不同但抓取时间不晚于已有版本、未写入的文章数
+}
+
+// MergeFrom 将另一个CSV输出目录（相同文件前缀）中的文章和重复标记合并到当前存储
+// 按规范ID去重：已存在且内容相同的文章跳过；内容不同时保留抓取时间较晚的版本，
+// 较新的文章按修订处理，旧版本归档到历史版本文件，因此重复合并的结果不变
+func (c *CSVStorage) MergeFrom(dir string) (*MergeResult, error) {
+	source := NewCSVStorage(dir, c.filePrefix)
+	monthKeys, err := source.listMonthKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{}
+	for _, monthKey := range monthKeys {
+		articles, err := source.readMonth(monthKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// 同一文件中的重复记录只保留最后一条
+		byID := make(map[int64]int, len(articles))
+		var unique []*models.Article
+		for _, article := range articles {
+			dedupe.Fingerprint(article)
+			if i, exists := byID[article.ID]; exists {
+				unique[i] = article
+				continue
+			}
+			byID[article.ID] = len(unique)
+			unique = append(unique, article)
+		}
+		result.Articles += len(unique)
+
+		c.mu.Lock()
+		if err := c.loadMonths([]string{monthKey}); err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		var newer []*models.Article
+		for _, article := range unique {
+			stored, exists := c.stored[article.ID]
+			switch {
+			case !exists:
+				result.Added++
+			case !articleChanged(stored.contentHash, stored.title, article):
+				result.Unchanged++
+				continue
+			case !article.CreatedAt.After(stored.createdAt):
+				result.Older++
+				continue
+			default:
+				result.Revised++
+			}
+			newer = append(newer, article)
+		}
+		c.mu.Unlock()
+
+		if err := c.SaveBatch(newer); err != nil {
+			return nil, fmt.Errorf("合并文章失败 [%s]: %v", monthKey, err)
+		}
+	}
+
+	marks, err := source.loadDuplicateMarks()
+	if err != nil {
+		return nil, err
+	}
+	if len(marks) > 0 {
+		merged, err := c.loadDuplicateMarks()
+		if err != nil {
+			return nil, err
+		}
+		for id, duplicateOf := range marks {
+			merged[id] = duplicateOf
+		}
+		if err := c.MarkDuplicates(merged); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// MergeJSONLines 将src中dst没有的行追加到dst末尾，返回追加的行数
+// 按整行去重，同一程序写出的相同记录序列化结果相同；保持原有顺序，后追加的记录仍在后面
+func MergeJSONLines(dst, src string) (int, error) {
+	seen := make(map[string]bool)
+	if err := scanLines(dst, func(line string) {
+		seen[line] = true
+	}); err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("读取文件失败 [%s]: %v", dst, err)
+	}
+
+	var lines []string
+	if err := scanLines(src, func(line string) {
+		if !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
+		}
+	}); err != nil {
+		return 0, fmt.Errorf("读取文件失败 [%s]: %v", src, err)
+	}
+	if len(lines) == 0 {
+		return 0, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return 0, fmt.Errorf("创建目录失败: %v", err)
+	}
+	file, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("打开文件失败 [%s]: %v", dst, err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	for _, line := range lines {
+		writer.WriteString(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		return 0, fmt.Errorf("写入文件失败 [%s]: %v", dst, err)
+	}
+	return len(lines), nil
+}
+
+// scanLines 逐行读取文件，跳过空行
+func scanLines(path string, fn func(line string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			fn(line)
+		}
+	}
+	return scanner.Err()
+}
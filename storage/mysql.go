@@ -6,15 +6,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Lan-ce-lot/data-people/dedupe"
 	"github.com/Lan-ce-lot/data-people/models"
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// hasBatchSize Has每次查询的ID数，避免IN列表过长
+const hasBatchSize = 500
+
 // MySQLStorage MySQL存储实现
 type MySQLStorage struct {
 	db       *sql.DB
 	dsn      string
 	prepared map[string]*sql.Stmt
+
+	dedupeIndex *dedupe.Index    // 保存时去重的指纹索引，nil表示不去重
+	legacyURLs  map[string]int64 // 迁移规范ID时早期文章URL对应的规范ID，迁移关联表时使用
 }
 
 // NewMySQLStorage 创建MySQL存储实例
@@ -52,22 +59,103 @@ func (m *MySQLStorage) Init() error {
 		return fmt.Errorf("创建表失败: %v", err)
 	}
 
+	// 创建抓取统计表（如果不存在）
+	if err := m.createRunsTable(); err != nil {
+		return fmt.Errorf("创建抓取统计表失败: %v", err)
+	}
+
+	// 创建文章历史版本表（如果不存在）
+	if err := m.createRevisionsTable(); err != nil {
+		return fmt.Errorf("创建历史版本表失败: %v", err)
+	}
+
+	// 创建文章图片表（如果不存在）
+	if err := m.createImagesTable(); err != nil {
+		return fmt.Errorf("创建文章图片表失败: %v", err)
+	}
+
+	// 创建文章表格表（如果不存在）
+	if err := m.createTablesTables(); err != nil {
+		return fmt.Errorf("创建文章表格表失败: %v", err)
+	}
+
+	// 创建报纸版面表（如果不存在）
+	if err := m.createIssuesTables(); err != nil {
+		return fmt.Errorf("创建报纸版面表失败: %v", err)
+	}
+
+	// 早期版本的图片、表格、历史版本和版面文章按URL关联文章，迁移为规范ID
+	if err := m.migrateChildArticleIDs(); err != nil {
+		return fmt.Errorf("迁移关联表失败: %v", err)
+	}
+
 	// 预编译SQL语句
 	if err := m.prepareSQLStatements(); err != nil {
 		return fmt.Errorf("预编译SQL语句失败: %v", err)
 	}
 
+	// 加载已有文章的指纹
+	if m.dedupeIndex != nil {
+		if err := m.loadFingerprints(); err != nil {
+			return fmt.Errorf("加载文章指纹失败: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// EnableDedupe 开启保存时去重，与已有文章海明距离不超过maxDistance的文章不再写入
+// 需要在Init之前调用
+func (m *MySQLStorage) EnableDedupe(maxDistance int) {
+	m.dedupeIndex = dedupe.NewIndex(maxDistance)
+}
+
+// loadFingerprints 将已有文章的指纹载入去重索引
+func (m *MySQLStorage) loadFingerprints() error {
+	rows, err := m.db.Query("SELECT id, content_hash, simhash FROM articles WHERE content_hash IS NOT NULL")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id          int64
+			contentHash string
+			simHash     uint64
+		)
+		if err := rows.Scan(&id, &contentHash, &simHash); err != nil {
+			return err
+		}
+		m.dedupeIndex.Add(id, contentHash, simHash)
+	}
+	return rows.Err()
+}
+
 // createTable 创建文章表
 func (m *MySQLStorage) createTable() error {
 	createTableSQL := `
 	CREATE TABLE IF NOT EXISTS articles (
-		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		id BIGINT PRIMARY KEY,
 		title VARCHAR(500) NOT NULL,
 		url VARCHAR(1000) NOT NULL,
+		permalink VARCHAR(1000),
+		subtitle VARCHAR(500),
+		raw TEXT,
+		edition VARCHAR(50),
+		type VARCHAR(100),
 		content LONGTEXT,
+		content_markdown LONGTEXT,
+		content_hash CHAR(64),
+		simhash BIGINT UNSIGNED,
+		duplicate_of BIGINT,
+		reporters VARCHAR(500),
+		agency VARCHAR(100),
+		dateline_location VARCHAR(100),
+		dateline_date DATE,
+		column_name VARCHAR(100),
+		ordinal INT,
+		page_coords VARCHAR(255),
 		summary TEXT,
 		publish_date DATETIME NOT NULL,
 		author VARCHAR(200),
@@ -75,31 +163,159 @@ func (m *MySQLStorage) createTable() error {
 		keywords VARCHAR(500),
 		category VARCHAR(100),
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		UNIQUE KEY uk_url (url),
+		INDEX idx_url (url(255)),
 		INDEX idx_publish_date (publish_date),
 		INDEX idx_author (author),
-		INDEX idx_category (category)
+		INDEX idx_category (category),
+		INDEX idx_edition (edition),
+		INDEX idx_type (type),
+		INDEX idx_content_hash (content_hash),
+		INDEX idx_agency (agency),
+		INDEX idx_dateline_location (dateline_location),
+		INDEX idx_column_name (column_name)
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 	`
 
-	_, err := m.db.Exec(createTableSQL)
-	return err
+	if _, err := m.db.Exec(createTableSQL); err != nil {
+		return err
+	}
+
+	// 早期版本创建的表缺少插入语句使用的列
+	columns := []struct {
+		name       string
+		definition string
+	}{
+		{"subtitle", "VARCHAR(500)"},
+		{"raw", "TEXT"},
+		{"edition", "VARCHAR(50)"},
+		{"type", "VARCHAR(100)"},
+		{"content_hash", "CHAR(64)"},
+		{"simhash", "BIGINT UNSIGNED"},
+		{"duplicate_of", "BIGINT"},
+		{"keywords", "VARCHAR(500)"},
+		{"summary", "TEXT"},
+		{"reporters", "VARCHAR(500)"},
+		{"agency", "VARCHAR(100)"},
+		{"dateline_location", "VARCHAR(100)"},
+		{"dateline_date", "DATE"},
+		{"column_name", "VARCHAR(100)"},
+		{"content_markdown", "LONGTEXT"},
+		{"ordinal", "INT"},
+		{"page_coords", "VARCHAR(255)"},
+		{"permalink", "VARCHAR(1000)"},
+	}
+	for _, column := range columns {
+		if err := m.ensureColumn("articles", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	// 早期版本以自增ID为主键、URL唯一，迁移为规范ID
+	if err := m.migrateArticleIDs(); err != nil {
+		return err
+	}
+	// 早期版本的重复标记为规范副本URL，迁移为规范ID
+	if err := m.migrateDuplicateMarks(); err != nil {
+		return err
+	}
+
+	indexes := []struct {
+		name       string
+		definition string
+	}{
+		{"idx_url", "INDEX idx_url (url(255))"},
+		{"idx_content_hash", "INDEX idx_content_hash (content_hash)"},
+		{"idx_agency", "INDEX idx_agency (agency)"},
+		{"idx_dateline_location", "INDEX idx_dateline_location (dateline_location)"},
+		{"idx_column_name", "INDEX idx_column_name (column_name)"},
+	}
+	for _, index := range indexes {
+		if err := m.ensureIndex("articles", index.name, index.definition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureColumn 如果列不存在则添加
+func (m *MySQLStorage) ensureColumn(table, column, definition string) error {
+	var count int
+	err := m.db.QueryRow(`
+		SELECT COUNT(*) FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`,
+		table, column).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("查询%s表结构失败: %v", table, err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if _, err := m.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("添加列%s.%s失败: %v", table, column, err)
+	}
+	return nil
+}
+
+// ensureIndex 如果索引不存在则添加
+func (m *MySQLStorage) ensureIndex(table, index, definition string) error {
+	exists, err := m.indexExists(table, index)
+	if err != nil || exists {
+		return err
+	}
+
+	if _, err := m.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD %s", table, definition)); err != nil {
+		return fmt.Errorf("添加索引%s.%s失败: %v", table, index, err)
+	}
+	return nil
+}
+
+// indexExists 检查索引是否存在
+func (m *MySQLStorage) indexExists(table, index string) (bool, error) {
+	var count int
+	err := m.db.QueryRow(`
+		SELECT COUNT(*) FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`,
+		table, index).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("查询%s表索引失败: %v", table, err)
+	}
+	return count > 0, nil
 }
 
 // prepareSQLStatements 预编译SQL语句
 func (m *MySQLStorage) prepareSQLStatements() error {
-	// 插入单条记录的SQL
+	// 插入单条记录的SQL，search方式抓取的文章没有版面位置，保留按版面抓取时记录的顺序和坐标
+	// created_at只在标题或正文变化时更新，与CSV存储一致；赋值按顺序执行，必须在title和content_hash之前
 	insertSQL := `
-	INSERT INTO articles (url, title, subtitle, raw, publish_date, edition, type, content, created_at)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO articles (id, url, permalink, title, subtitle, raw, publish_date, edition, type, content, content_markdown,
+		summary, keywords, content_hash, simhash,
+		reporters, agency, dateline_location, dateline_date, column_name, ordinal, page_coords, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	ON DUPLICATE KEY UPDATE
+		created_at = IF(content_hash <=> VALUES(content_hash) AND title = VALUES(title), created_at, VALUES(created_at)),
+		url = VALUES(url),
+		permalink = VALUES(permalink),
 		title = VALUES(title),
 		subtitle = VALUES(subtitle),
 		raw = VALUES(raw),
 		publish_date = VALUES(publish_date),
 		edition = VALUES(edition),
 		type = VALUES(type),
-		content = VALUES(content)
+		content = VALUES(content),
+		content_markdown = VALUES(content_markdown),
+		summary = VALUES(summary),
+		keywords = VALUES(keywords),
+		content_hash = VALUES(content_hash),
+		simhash = VALUES(simhash),
+		reporters = VALUES(reporters),
+		agency = VALUES(agency),
+		dateline_location = VALUES(dateline_location),
+		dateline_date = VALUES(dateline_date),
+		column_name = VALUES(column_name),
+		ordinal = COALESCE(VALUES(ordinal), ordinal),
+		page_coords = COALESCE(VALUES(page_coords), page_coords)
 	`
 
 	stmt, err := m.db.Prepare(insertSQL)
@@ -118,6 +334,13 @@ func (m *MySQLStorage) Save(article *models.Article) error {
 
 // SaveBatch 批量保存文章
 func (m *MySQLStorage) SaveBatch(articles []*models.Article) error {
+	// 未经处理流程的文章在这里补充规范ID
+	for _, article := range articles {
+		article.EnsureID()
+	}
+
+	// 计算指纹，开启去重时跳过重复文章
+	articles = suppressDuplicates(m.dedupeIndex, articles, m.GetStorageType())
 	if len(articles) == 0 {
 		return nil
 	}
@@ -129,6 +352,11 @@ func (m *MySQLStorage) SaveBatch(articles []*models.Article) error {
 	}
 	defer tx.Rollback()
 
+	// 标题或正文变化的文章先归档旧版本，避免被覆盖
+	if err := m.archiveRevisions(tx, articles); err != nil {
+		return err
+	}
+
 	// 准备批量插入语句
 	stmt := tx.Stmt(m.prepared["insert"])
 	defer stmt.Close()
@@ -136,7 +364,9 @@ func (m *MySQLStorage) SaveBatch(articles []*models.Article) error {
 	// 批量插入
 	for _, article := range articles {
 		_, err := stmt.Exec(
+			article.ID,
 			article.URL,
+			nullableString(article.Permalink),
 			article.Title,
 			article.Subtitle,
 			article.Raw,
@@ -144,6 +374,18 @@ func (m *MySQLStorage) SaveBatch(articles []*models.Article) error {
 			article.Edition,
 			article.Type,
 			article.Content,
+			article.ContentMarkdown,
+			article.Summary,
+			article.Keywords,
+			nullableString(article.ContentHash),
+			article.SimHash,
+			strings.Join(article.Reporters, ","),
+			article.Agency,
+			article.DatelineLocation,
+			nullableTime(article.DatelineDate),
+			nullableString(article.Column),
+			nullableInt(article.Ordinal),
+			nullableString(article.PageCoords),
 			article.CreatedAt,
 		)
 		if err != nil {
@@ -151,6 +393,14 @@ func (m *MySQLStorage) SaveBatch(articles []*models.Article) error {
 		}
 	}
 
+	// 图片和表格写入单独的表
+	if err := m.saveImages(tx, articles); err != nil {
+		return err
+	}
+	if err := m.saveTables(tx, articles); err != nil {
+		return err
+	}
+
 	// 提交事务
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("提交事务失败: %v", err)
@@ -159,6 +409,101 @@ func (m *MySQLStorage) SaveBatch(articles []*models.Article) error {
 	return nil
 }
 
+// MarkDuplicates 用新的标记替换articles表中的全部重复标记
+func (m *MySQLStorage) MarkDuplicates(marks map[int64]int64) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE articles SET duplicate_of = NULL WHERE duplicate_of IS NOT NULL"); err != nil {
+		return fmt.Errorf("清除重复标记失败: %v", err)
+	}
+
+	stmt, err := tx.Prepare("UPDATE articles SET duplicate_of = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("预编译更新语句失败: %v", err)
+	}
+	defer stmt.Close()
+
+	for id, canonical := range marks {
+		if _, err := stmt.Exec(canonical, id); err != nil {
+			return fmt.Errorf("标记重复文章失败 [%d]: %v", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %v", err)
+	}
+	return nil
+}
+
+// Has 按主键分批查询规范ID是否已存储
+func (m *MySQLStorage) Has(ids []int64) (map[int64]bool, error) {
+	found := make(map[int64]bool)
+
+	for start := 0; start < len(ids); start += hasBatchSize {
+		end := start + hasBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			placeholders[i] = "?"
+			args[i] = id
+		}
+
+		rows, err := m.db.Query("SELECT id FROM articles WHERE id IN ("+strings.Join(placeholders, ", ")+")", args...)
+		if err != nil {
+			return nil, fmt.Errorf("查询已存储文章失败: %v", err)
+		}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("读取文章ID失败: %v", err)
+			}
+			found[id] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("查询已存储文章失败: %v", err)
+		}
+	}
+
+	return found, nil
+}
+
+// UpdateDerived 按规范ID更新文章的关键词、摘要和栏目
+func (m *MySQLStorage) UpdateDerived(articles []*models.Article) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %v", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("UPDATE articles SET keywords = ?, summary = ?, column_name = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("预编译更新语句失败: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, article := range articles {
+		if _, err := stmt.Exec(article.Keywords, article.Summary, nullableString(article.Column), article.ID); err != nil {
+			return fmt.Errorf("更新文章失败 [%s]: %v", article.URL, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %v", err)
+	}
+	return nil
+}
+
 // Close 关闭数据库连接
 func (m *MySQLStorage) Close() error {
 	var errs []string
@@ -190,29 +535,69 @@ func (m *MySQLStorage) GetStorageType() string {
 }
 
 // GetStats 获取存储统计信息
-func (m *MySQLStorage) GetStats() (map[string]interface{}, error) {
-	stats := make(map[string]interface{})
-
-	// 获取文章总数
-	var totalCount int
-	err := m.db.QueryRow("SELECT COUNT(*) FROM articles").Scan(&totalCount)
+func (m *MySQLStorage) GetStats(filter ArticleFilter) (*models.CorpusStats, error) {
+	stats := &models.CorpusStats{}
+	where, args := buildWhere(filter)
+
+	// 获取文章总数、平均正文长度和日期范围
+	var (
+		avgLength                sql.NullFloat64
+		earliestDate, latestDate sql.NullTime
+	)
+	err := m.db.QueryRow("SELECT COUNT(*), AVG(CHAR_LENGTH(content)), MIN(publish_date), MAX(publish_date) FROM articles"+where, args...).
+		Scan(&stats.TotalArticles, &avgLength, &earliestDate, &latestDate)
 	if err != nil {
 		return nil, fmt.Errorf("获取文章总数失败: %v", err)
 	}
-	stats["total_articles"] = totalCount
-
-	// 获取最早和最晚的文章日期
-	var earliestDate, latestDate sql.NullTime
-	err = m.db.QueryRow("SELECT MIN(publish_date), MAX(publish_date) FROM articles").Scan(&earliestDate, &latestDate)
-	if err != nil {
-		return nil, fmt.Errorf("获取日期范围失败: %v", err)
-	}
+	stats.AvgContentLength = avgLength.Float64
 	if earliestDate.Valid {
-		stats["earliest_date"] = earliestDate.Time.Format("2006-01-02")
+		stats.EarliestDate = earliestDate.Time.Format("2006-01-02")
 	}
 	if latestDate.Valid {
-		stats["latest_date"] = latestDate.Time.Format("2006-01-02")
+		stats.LatestDate = latestDate.Time.Format("2006-01-02")
+	}
+
+	// 按年份、月份、版次、类型分组统计
+	groups := []struct {
+		expr   string
+		target *[]models.StatCount
+	}{
+		{"DATE_FORMAT(publish_date, '%Y')", &stats.ByYear},
+		{"DATE_FORMAT(publish_date, '%Y-%m')", &stats.ByMonth},
+		{"COALESCE(edition, '')", &stats.ByEdition},
+		{"COALESCE(type, '')", &stats.ByType},
+	}
+	for _, group := range groups {
+		counts, err := m.countBy(group.expr, where, args)
+		if err != nil {
+			return nil, err
+		}
+		*group.target = counts
 	}
+	stats.ByEdition = sortedEditionCounts(toCountMap(stats.ByEdition))
 
 	return stats, nil
 }
+
+// countBy 按表达式分组计数
+func (m *MySQLStorage) countBy(expr, where string, args []interface{}) ([]models.StatCount, error) {
+	query := fmt.Sprintf("SELECT %s AS k, COUNT(*) FROM articles%s GROUP BY k ORDER BY k", expr, where)
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("分组统计失败: %v", err)
+	}
+	defer rows.Close()
+
+	var counts []models.StatCount
+	for rows.Next() {
+		var (
+			key   sql.NullString
+			count int
+		)
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, fmt.Errorf("读取分组统计失败: %v", err)
+		}
+		counts = append(counts, models.StatCount{Key: key.String, Count: count})
+	}
+	return counts, rows.Err()
+}
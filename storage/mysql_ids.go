@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// migrateArticleIDs 将早期版本以自增ID为主键、URL唯一的文章表迁移为以规范ID为主键
+// 规范ID相同的文章（同一篇文章的不同检索URL）只保留最近抓取的一条；
+// 迁移完成后删除URL唯一索引，该索引不存在时不做任何处理
+func (m *MySQLStorage) migrateArticleIDs() error {
+	exists, err := m.indexExists("articles", "uk_url")
+	if err != nil || !exists {
+		return err
+	}
+	log.Printf("迁移文章表为规范ID...")
+
+	rows, err := m.db.Query(`
+	SELECT id, url, permalink, title, publish_date, edition
+	FROM articles ORDER BY created_at, id`)
+	if err != nil {
+		return fmt.Errorf("查询文章失败: %v", err)
+	}
+
+	var (
+		canonical = make(map[int64]int64) // 旧ID -> 规范ID，按抓取时间升序写入
+		latest    = make(map[int64]int64) // 规范ID -> 最近抓取的旧ID
+	)
+	// 合并掉的重复文章的URL也要保留，迁移关联表时使用
+	m.legacyURLs = make(map[string]int64)
+	for rows.Next() {
+		var (
+			oldID              int64
+			article            models.Article
+			permalink, edition sql.NullString
+			publishDate        sql.NullTime
+		)
+		if err := rows.Scan(&oldID, &article.URL, &permalink, &article.Title, &publishDate, &edition); err != nil {
+			rows.Close()
+			return fmt.Errorf("读取文章失败: %v", err)
+		}
+		article.Permalink = permalink.String
+		article.PublishDate = publishDate.Time
+		article.Edition = edition.String
+
+		id := models.CanonicalID(&article)
+		canonical[oldID] = id
+		latest[id] = oldID
+		m.legacyURLs[article.URL] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("读取文章失败: %v", err)
+	}
+
+	// 主键由程序生成，不再自增
+	if _, err := m.db.Exec("ALTER TABLE articles MODIFY id BIGINT NOT NULL"); err != nil {
+		return fmt.Errorf("修改文章ID列失败: %v", err)
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %v", err)
+	}
+	defer tx.Rollback()
+
+	// 先把旧ID改为负数，避免与规范ID冲突
+	if _, err := tx.Exec("UPDATE articles SET id = -id WHERE id > 0"); err != nil {
+		return fmt.Errorf("迁移文章ID失败: %v", err)
+	}
+	removed := 0
+	for oldID, id := range canonical {
+		if latest[id] != oldID {
+			if _, err := tx.Exec("DELETE FROM articles WHERE id = ?", -oldID); err != nil {
+				return fmt.Errorf("删除重复文章失败: %v", err)
+			}
+			removed++
+			continue
+		}
+		if _, err := tx.Exec("UPDATE articles SET id = ? WHERE id = ?", id, -oldID); err != nil {
+			return fmt.Errorf("迁移文章ID失败: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %v", err)
+	}
+
+	if _, err := m.db.Exec("ALTER TABLE articles DROP INDEX uk_url"); err != nil {
+		return fmt.Errorf("删除URL唯一索引失败: %v", err)
+	}
+	log.Printf("✓ 迁移 %d 篇文章为规范ID，合并重复文章 %d 篇", len(canonical)-removed, removed)
+	return nil
+}
+
+// migrateDuplicateMarks 将早期版本以规范副本URL记录的重复标记转换为规范ID
+// 找不到规范副本的标记被清除，可重新运行 dedupe --mark 生成
+func (m *MySQLStorage) migrateDuplicateMarks() error {
+	dataType, err := m.columnType("articles", "duplicate_of")
+	if err != nil || dataType == "bigint" {
+		return err
+	}
+	log.Printf("迁移重复标记为规范ID...")
+
+	if err := m.ensureColumn("articles", "duplicate_of_id", "BIGINT"); err != nil {
+		return err
+	}
+	result, err := m.db.Exec(`
+	UPDATE articles d JOIN articles c ON c.url = d.duplicate_of
+	SET d.duplicate_of_id = c.id
+	WHERE d.duplicate_of IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("转换重复标记失败: %v", err)
+	}
+	converted, _ := result.RowsAffected()
+
+	if _, err := m.db.Exec("ALTER TABLE articles DROP COLUMN duplicate_of"); err != nil {
+		return fmt.Errorf("删除重复标记列失败: %v", err)
+	}
+	if _, err := m.db.Exec("ALTER TABLE articles CHANGE duplicate_of_id duplicate_of BIGINT"); err != nil {
+		return fmt.Errorf("重命名重复标记列失败: %v", err)
+	}
+	log.Printf("✓ 迁移重复标记 %d 条", converted)
+	return nil
+}
+
+// columnType 返回列的数据类型（小写，如 bigint、varchar），列不存在时返回空
+func (m *MySQLStorage) columnType(table, column string) (string, error) {
+	var dataType string
+	err := m.db.QueryRow(`
+		SELECT LOWER(DATA_TYPE) FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`,
+		table, column).Scan(&dataType)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("查询%s表结构失败: %v", table, err)
+	}
+	return dataType, nil
+}
+
+// migrateChildArticleIDs 为早期版本按URL关联文章的图片、表格、版面文章和历史版本补充规范ID
+// 按URL依次尝试：迁移规范ID时记录的对应关系、URL中的站点文档ID、当前文章表中的URL；
+// 历史版本最后按自身的标题、日期和版次计算规范ID。仍无法确定的记录保持为空，不再被查询
+func (m *MySQLStorage) migrateChildArticleIDs() error {
+	for _, table := range []string{"article_images", "article_tables", "page_articles"} {
+		if err := m.migrateChildTable(table); err != nil {
+			return err
+		}
+	}
+	return m.migrateRevisionArticleIDs()
+}
+
+// prepareChildColumn 为关联表添加可为空的article_id列和索引，返回仍缺少规范ID的记录数
+func (m *MySQLStorage) prepareChildColumn(table string) (int, error) {
+	if err := m.ensureColumn(table, "article_id", "BIGINT"); err != nil {
+		return 0, err
+	}
+	if err := m.ensureIndex(table, "idx_article_id", "INDEX idx_article_id (article_id)"); err != nil {
+		return 0, err
+	}
+
+	var missing int
+	if err := m.db.QueryRow("SELECT COUNT(*) FROM " + table + " WHERE article_id IS NULL").Scan(&missing); err != nil {
+		return 0, fmt.Errorf("查询%s失败: %v", table, err)
+	}
+	return missing, nil
+}
+
+// migrateChildTable 按article_url为关联表补充规范ID
+func (m *MySQLStorage) migrateChildTable(table string) error {
+	missing, err := m.prepareChildColumn(table)
+	if err != nil || missing == 0 {
+		return err
+	}
+
+	rows, err := m.db.Query("SELECT DISTINCT article_url FROM " + table + " WHERE article_id IS NULL")
+	if err != nil {
+		return fmt.Errorf("查询%s失败: %v", table, err)
+	}
+	var urls []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			rows.Close()
+			return fmt.Errorf("读取%s失败: %v", table, err)
+		}
+		urls = append(urls, url)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("读取%s失败: %v", table, err)
+	}
+
+	resolved := 0
+	for _, url := range urls {
+		id, err := m.resolveArticleID(url)
+		if err != nil {
+			return err
+		}
+		if id == 0 {
+			continue
+		}
+		if _, err := m.db.Exec("UPDATE "+table+" SET article_id = ? WHERE article_url = ? AND article_id IS NULL", id, url); err != nil {
+			return fmt.Errorf("更新%s失败: %v", table, err)
+		}
+		resolved++
+	}
+	log.Printf("✓ %s 补充规范ID: %d/%d 个文章URL", table, resolved, len(urls))
+	return nil
+}
+
+// migrateRevisionArticleIDs 为历史版本补充规范ID
+func (m *MySQLStorage) migrateRevisionArticleIDs() error {
+	missing, err := m.prepareChildColumn("article_revisions")
+	if err != nil || missing == 0 {
+		return err
+	}
+
+	rows, err := m.db.Query(`
+	SELECT id, url, title, publish_date, edition
+	FROM article_revisions WHERE article_id IS NULL`)
+	if err != nil {
+		return fmt.Errorf("查询历史版本失败: %v", err)
+	}
+	ids := make(map[int64]*models.Article)
+	for rows.Next() {
+		var (
+			rowID          int64
+			article        models.Article
+			title, edition sql.NullString
+			publishDate    sql.NullTime
+		)
+		if err := rows.Scan(&rowID, &article.URL, &title, &publishDate, &edition); err != nil {
+			rows.Close()
+			return fmt.Errorf("读取历史版本失败: %v", err)
+		}
+		article.Title = title.String
+		article.PublishDate = publishDate.Time
+		article.Edition = edition.String
+		ids[rowID] = &article
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("读取历史版本失败: %v", err)
+	}
+
+	for rowID, article := range ids {
+		id, err := m.resolveArticleID(article.URL)
+		if err != nil {
+			return err
+		}
+		if id == 0 {
+			id = models.CanonicalID(article)
+		}
+		if _, err := m.db.Exec("UPDATE article_revisions SET article_id = ? WHERE id = ?", id, rowID); err != nil {
+			return fmt.Errorf("更新历史版本失败: %v", err)
+		}
+	}
+	log.Printf("✓ article_revisions 补充规范ID: %d 条", len(ids))
+	return nil
+}
+
+// resolveArticleID 查找URL对应文章的规范ID，找不到时返回0
+func (m *MySQLStorage) resolveArticleID(url string) (int64, error) {
+	if id, ok := m.legacyURLs[url]; ok {
+		return id, nil
+	}
+	if id := models.DocumentID(url); id > 0 {
+		return id, nil
+	}
+
+	var id int64
+	err := m.db.QueryRow("SELECT id FROM articles WHERE url = ? LIMIT 1", url).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("查询文章失败: %v", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// createImagesTable 创建文章图片表
+func (m *MySQLStorage) createImagesTable() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS article_images (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		article_id BIGINT NOT NULL,
+		article_url VARCHAR(1000) NOT NULL,
+		position INT NOT NULL,
+		url VARCHAR(1000) NOT NULL,
+		caption VARCHAR(1000),
+		local_path VARCHAR(500),
+		sha256 CHAR(64),
+		size BIGINT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_article_id (article_id),
+		INDEX idx_sha256 (sha256)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	_, err := m.db.Exec(createTableSQL)
+	return err
+}
+
+// saveImages 在同一事务中用批次内文章的图片替换已存储的图片
+func (m *MySQLStorage) saveImages(tx *sql.Tx, articles []*models.Article) error {
+	deleteStmt, err := tx.Prepare("DELETE FROM article_images WHERE article_id = ?")
+	if err != nil {
+		return fmt.Errorf("预编译删除图片语句失败: %v", err)
+	}
+	defer deleteStmt.Close()
+
+	insertStmt, err := tx.Prepare(`
+	INSERT INTO article_images (article_id, article_url, position, url, caption, local_path, sha256, size)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("预编译插入图片语句失败: %v", err)
+	}
+	defer insertStmt.Close()
+
+	for _, article := range articles {
+		if _, err := deleteStmt.Exec(article.ID); err != nil {
+			return fmt.Errorf("删除文章图片失败 [%s]: %v", article.URL, err)
+		}
+		for i, image := range article.Images {
+			_, err := insertStmt.Exec(article.ID, article.URL, i, image.URL, nullableString(image.Caption),
+				nullableString(image.LocalPath), nullableString(image.SHA256), image.Size)
+			if err != nil {
+				return fmt.Errorf("插入文章图片失败 [%s]: %v", image.URL, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetImages 按出现顺序返回规范ID对应文章的图片
+func (m *MySQLStorage) GetImages(id int64) ([]models.ArticleImage, error) {
+	rows, err := m.db.Query(`
+	SELECT url, caption, local_path, sha256, size
+	FROM article_images WHERE article_id = ? ORDER BY position`, id)
+	if err != nil {
+		return nil, fmt.Errorf("查询文章图片失败: %v", err)
+	}
+	defer rows.Close()
+
+	var images []models.ArticleImage
+	for rows.Next() {
+		var (
+			image                      models.ArticleImage
+			caption, localPath, sha256 sql.NullString
+			size                       sql.NullInt64
+		)
+		if err := rows.Scan(&image.URL, &caption, &localPath, &sha256, &size); err != nil {
+			return nil, fmt.Errorf("读取文章图片失败: %v", err)
+		}
+		image.Caption = caption.String
+		image.LocalPath = localPath.String
+		image.SHA256 = sha256.String
+		image.Size = size.Int64
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
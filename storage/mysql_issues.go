@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// createIssuesTables 创建报纸、版面和版面文章表
+func (m *MySQLStorage) createIssuesTables() error {
+	statements := []string{`
+	CREATE TABLE IF NOT EXISTS issues (
+		issue_date DATE PRIMARY KEY,
+		total_editions INT NOT NULL,
+		crawled_at DATETIME,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`, `
+	CREATE TABLE IF NOT EXISTS pages (
+		issue_date DATE NOT NULL,
+		edition INT NOT NULL,
+		name VARCHAR(100),
+		url VARCHAR(1000),
+		pdf_url VARCHAR(1000),
+		PRIMARY KEY (issue_date, edition)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`, `
+	CREATE TABLE IF NOT EXISTS page_articles (
+		issue_date DATE NOT NULL,
+		edition INT NOT NULL,
+		ordinal INT NOT NULL,
+		article_id BIGINT,
+		article_url VARCHAR(1000) NOT NULL,
+		title VARCHAR(500),
+		coords VARCHAR(255),
+		PRIMARY KEY (issue_date, edition, ordinal),
+		INDEX idx_article_id (article_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`}
+
+	for _, statement := range statements {
+		if _, err := m.db.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveIssue 在一个事务中用新的版面替换该日期已存储的版面
+func (m *MySQLStorage) SaveIssue(issue *models.Issue) error {
+	day := issue.Date.Format("2006-01-02")
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %v", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+	INSERT INTO issues (issue_date, total_editions, crawled_at) VALUES (?, ?, ?)
+	ON DUPLICATE KEY UPDATE total_editions = VALUES(total_editions), crawled_at = VALUES(crawled_at)`,
+		day, issue.TotalEditions, nullableTime(issue.CrawledAt))
+	if err != nil {
+		return fmt.Errorf("保存报纸失败 [%s]: %v", day, err)
+	}
+
+	for _, table := range []string{"page_articles", "pages"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE issue_date = ?", day); err != nil {
+			return fmt.Errorf("删除旧版面失败 [%s]: %v", day, err)
+		}
+	}
+
+	for _, page := range issue.Pages {
+		_, err := tx.Exec("INSERT INTO pages (issue_date, edition, name, url, pdf_url) VALUES (?, ?, ?, ?, ?)",
+			day, page.Edition, nullableString(page.Name), nullableString(page.URL), nullableString(page.PDFURL))
+		if err != nil {
+			return fmt.Errorf("保存版面失败 [%s 第%d版]: %v", day, page.Edition, err)
+		}
+		for _, article := range page.Articles {
+			_, err := tx.Exec(`
+			INSERT INTO page_articles (issue_date, edition, ordinal, article_id, article_url, title, coords)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				day, page.Edition, article.Ordinal, nullableID(models.DocumentID(article.URL)), article.URL,
+				nullableString(article.Title), nullableString(article.Coords))
+			if err != nil {
+				return fmt.Errorf("保存版面文章失败 [%s]: %v", article.URL, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %v", err)
+	}
+	return nil
+}
+
+// GetIssue 获取指定日期的报纸，版面按版次、文章按版面中的顺序排列
+func (m *MySQLStorage) GetIssue(date time.Time) (*models.Issue, error) {
+	day := date.Format("2006-01-02")
+	issue := &models.Issue{}
+
+	var crawledAt sql.NullTime
+	err := m.db.QueryRow("SELECT issue_date, total_editions, crawled_at FROM issues WHERE issue_date = ?", day).
+		Scan(&issue.Date, &issue.TotalEditions, &crawledAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("没有 %s 的版面记录", day)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("查询报纸失败: %v", err)
+	}
+	issue.CrawledAt = crawledAt.Time
+
+	rows, err := m.db.Query(`
+	SELECT p.edition, p.name, p.url, p.pdf_url, a.ordinal, a.article_url, a.title, a.coords
+	FROM pages p LEFT JOIN page_articles a ON a.issue_date = p.issue_date AND a.edition = p.edition
+	WHERE p.issue_date = ?
+	ORDER BY p.edition, a.ordinal`, day)
+	if err != nil {
+		return nil, fmt.Errorf("查询版面失败: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			edition                   int
+			name, url, pdfURL         sql.NullString
+			ordinal                   sql.NullInt64
+			articleURL, title, coords sql.NullString
+		)
+		if err := rows.Scan(&edition, &name, &url, &pdfURL, &ordinal, &articleURL, &title, &coords); err != nil {
+			return nil, fmt.Errorf("读取版面失败: %v", err)
+		}
+
+		if len(issue.Pages) == 0 || issue.Pages[len(issue.Pages)-1].Edition != edition {
+			issue.Pages = append(issue.Pages, models.Page{
+				Edition: edition,
+				Name:    name.String,
+				URL:     url.String,
+				PDFURL:  pdfURL.String,
+			})
+		}
+		if articleURL.Valid {
+			page := &issue.Pages[len(issue.Pages)-1]
+			page.Articles = append(page.Articles, models.PageArticle{
+				Ordinal: int(ordinal.Int64),
+				URL:     articleURL.String,
+				Title:   title.String,
+				Coords:  coords.String,
+			})
+		}
+	}
+
+	return issue, rows.Err()
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// articleSelectSQL 查询文章的公共字段列表
+const articleSelectSQL = `
+	SELECT id, url, title, subtitle, raw, publish_date, edition, type, content, created_at,
+		content_hash, simhash, duplicate_of, keywords, summary,
+		reporters, agency, dateline_location, dateline_date, column_name, content_markdown,
+		ordinal, page_coords, permalink
+	FROM articles`
+
+// ForEach 按 (发布日期, 规范ID) 升序遍历满足条件的文章
+// 二级索引包含主键，idx_publish_date 即可满足该顺序
+func (m *MySQLStorage) ForEach(filter ArticleFilter, fn func(article *models.Article) error) error {
+	where, args := buildWhere(filter)
+	if filter.After != nil {
+		if where == "" {
+			where = " WHERE "
+		} else {
+			where += " AND "
+		}
+		where += "(publish_date > ? OR (publish_date = ? AND id > ?))"
+		args = append(args, filter.After.PublishDate, filter.After.PublishDate, filter.After.ID)
+	}
+
+	query := articleSelectSQL + where + " ORDER BY publish_date, id"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("查询文章失败: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		article, err := scanArticle(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(article); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// buildWhere 根据过滤条件构建WHERE子句（不含游标和条数限制）
+func buildWhere(filter ArticleFilter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "publish_date >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "publish_date < ?")
+		args = append(args, filter.To.AddDate(0, 0, 1))
+	}
+	if filter.Edition != "" {
+		conditions = append(conditions, "edition = ?")
+		args = append(args, filter.Edition)
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.Column != "" {
+		conditions = append(conditions, "column_name = ?")
+		args = append(args, filter.Column)
+	}
+	if filter.Keyword != "" {
+		conditions = append(conditions, "title LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Keyword)+"%")
+	}
+	if filter.ID != 0 {
+		conditions = append(conditions, "id = ?")
+		args = append(args, filter.ID)
+	}
+	if filter.URL != "" {
+		conditions = append(conditions, "url = ?")
+		args = append(args, filter.URL)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// scanArticle 将一行articles记录转换为文章
+func scanArticle(row rowScanner) (*models.Article, error) {
+	var (
+		article                           models.Article
+		subtitle, raw, edition, typ, body sql.NullString
+		contentHash                       sql.NullString
+		words, summary                    sql.NullString
+		reporters, agency, location       sql.NullString
+		column, markdown, coords          sql.NullString
+		permalink                         sql.NullString
+		ordinal, duplicateOf              sql.NullInt64
+		simHash                           *uint64
+		publishDate, createdAt, dateline  sql.NullTime
+	)
+
+	err := row.Scan(&article.ID, &article.URL, &article.Title, &subtitle, &raw,
+		&publishDate, &edition, &typ, &body, &createdAt,
+		&contentHash, &simHash, &duplicateOf, &words, &summary,
+		&reporters, &agency, &location, &dateline, &column, &markdown,
+		&ordinal, &coords, &permalink)
+	if err != nil {
+		return nil, fmt.Errorf("读取文章失败: %v", err)
+	}
+
+	article.Subtitle = subtitle.String
+	article.Raw = raw.String
+	article.PublishDate = publishDate.Time
+	article.Edition = edition.String
+	article.Type = typ.String
+	article.Content = body.String
+	article.CreatedAt = createdAt.Time
+	article.ContentHash = contentHash.String
+	if simHash != nil {
+		article.SimHash = *simHash
+	}
+	article.DuplicateOf = duplicateOf.Int64
+	article.Keywords = words.String
+	article.Summary = summary.String
+	article.Agency = agency.String
+	article.DatelineLocation = location.String
+	article.DatelineDate = dateline.Time
+	article.Column = column.String
+	article.ContentMarkdown = markdown.String
+	article.Ordinal = int(ordinal.Int64)
+	article.PageCoords = coords.String
+	article.Permalink = permalink.String
+	if reporters.String != "" {
+		article.Reporters = strings.Split(reporters.String, ",")
+	}
+
+	return &article, nil
+}
+
+// escapeLike 转义LIKE模式中的通配符
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(value)
+}
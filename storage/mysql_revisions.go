@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/dedupe"
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// createRevisionsTable 创建文章历史版本表
+func (m *MySQLStorage) createRevisionsTable() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS article_revisions (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		article_id BIGINT NOT NULL,
+		url VARCHAR(1000) NOT NULL,
+		title VARCHAR(500),
+		subtitle VARCHAR(500),
+		raw TEXT,
+		publish_date DATETIME,
+		edition VARCHAR(50),
+		type VARCHAR(100),
+		content LONGTEXT,
+		content_hash CHAR(64),
+		crawled_at TIMESTAMP NULL,
+		archived_at DATETIME NOT NULL,
+		INDEX idx_article_id (article_id),
+		INDEX idx_archived_at (archived_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	_, err := m.db.Exec(createTableSQL)
+	return err
+}
+
+// archiveRevisions 在同一事务中查出批次内已存储的文章，标题或正文变化的旧版本写入历史版本表
+func (m *MySQLStorage) archiveRevisions(tx *sql.Tx, articles []*models.Article) error {
+	placeholders := make([]string, len(articles))
+	args := make([]interface{}, len(articles))
+	for i, article := range articles {
+		placeholders[i] = "?"
+		args[i] = article.ID
+	}
+
+	query := articleSelectSQL + " WHERE id IN (" + strings.Join(placeholders, ", ") + ") FOR UPDATE"
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("查询已存储文章失败: %v", err)
+	}
+	existing := make(map[int64]*models.Article)
+	for rows.Next() {
+		article, err := scanArticle(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		existing[article.ID] = article
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("查询已存储文章失败: %v", err)
+	}
+
+	insertSQL := `
+	INSERT INTO article_revisions (article_id, url, title, subtitle, raw, publish_date, edition, type, content,
+		content_hash, crawled_at, archived_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	now := time.Now()
+	for _, article := range articles {
+		old, exists := existing[article.ID]
+		if !exists {
+			continue
+		}
+		dedupe.Fingerprint(old)
+		if !articleChanged(old.ContentHash, old.Title, article) {
+			continue
+		}
+
+		_, err := tx.Exec(insertSQL,
+			old.ID,
+			old.URL,
+			old.Title,
+			old.Subtitle,
+			old.Raw,
+			old.PublishDate,
+			old.Edition,
+			old.Type,
+			old.Content,
+			nullableString(old.ContentHash),
+			old.CreatedAt,
+			now,
+		)
+		if err != nil {
+			return fmt.Errorf("写入历史版本失败 [%s]: %v", old.URL, err)
+		}
+		// 同一批次中重复出现的文章只与数据库中的版本比较一次
+		delete(existing, article.ID)
+	}
+
+	return nil
+}
+
+// GetRevisions 按归档时间升序返回规范ID对应文章的历史版本
+func (m *MySQLStorage) GetRevisions(id int64) ([]*models.Revision, error) {
+	rows, err := m.db.Query(`
+	SELECT article_id, url, title, subtitle, raw, publish_date, edition, type, content, content_hash,
+		crawled_at, archived_at
+	FROM article_revisions WHERE article_id = ? ORDER BY archived_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("查询历史版本失败: %v", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.Revision
+	for rows.Next() {
+		var (
+			revision                                       models.Revision
+			title, subtitle, raw, edition, typ, body, hash sql.NullString
+			publishDate, crawledAt                         sql.NullTime
+		)
+		article := &revision.Article
+		err := rows.Scan(&article.ID, &article.URL, &title, &subtitle, &raw, &publishDate,
+			&edition, &typ, &body, &hash, &crawledAt, &revision.ArchivedAt)
+		if err != nil {
+			return nil, fmt.Errorf("读取历史版本失败: %v", err)
+		}
+
+		article.Title = title.String
+		article.Subtitle = subtitle.String
+		article.Raw = raw.String
+		article.PublishDate = publishDate.Time
+		article.Edition = edition.String
+		article.Type = typ.String
+		article.Content = body.String
+		article.ContentHash = hash.String
+		article.CreatedAt = crawledAt.Time
+		revisions = append(revisions, &revision)
+	}
+
+	return revisions, rows.Err()
+}
@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// createRunsTable 创建抓取统计表，并迁移旧版按日期唯一的表结构
+func (m *MySQLStorage) createRunsTable() error {
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS crawl_stats (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		run_id VARCHAR(64) NOT NULL,
+		config_hash VARCHAR(64),
+		crawl_date DATE NOT NULL,
+		range_start DATE,
+		range_end DATE,
+		status VARCHAR(20),
+		total_tasks INT DEFAULT 0,
+		articles_count INT DEFAULT 0,
+		success_count INT DEFAULT 0,
+		failed_count INT DEFAULT 0,
+		quarantined_count INT DEFAULT 0,
+		skipped_count INT DEFAULT 0,
+		start_time DATETIME,
+		end_time DATETIME,
+		duration_seconds INT,
+		reported_totals TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE KEY uk_run_id (run_id),
+		INDEX idx_crawl_date (crawl_date)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	if _, err := m.db.Exec(createTableSQL); err != nil {
+		return err
+	}
+
+	// 旧版schema.sql创建的表缺少运行相关字段，且每天只能有一条记录
+	columns := []struct {
+		name       string
+		definition string
+	}{
+		{"run_id", "VARCHAR(64) NULL"},
+		{"config_hash", "VARCHAR(64)"},
+		{"range_start", "DATE"},
+		{"range_end", "DATE"},
+		{"status", "VARCHAR(20)"},
+		{"total_tasks", "INT DEFAULT 0"},
+		{"reported_totals", "TEXT"},
+		{"quarantined_count", "INT DEFAULT 0"},
+		{"skipped_count", "INT DEFAULT 0"},
+	}
+	for _, column := range columns {
+		if err := m.ensureColumn("crawl_stats", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	exists, err := m.indexExists("crawl_stats", "uk_crawl_date")
+	if err != nil {
+		return err
+	}
+	if exists {
+		if _, err := m.db.Exec("ALTER TABLE crawl_stats DROP INDEX uk_crawl_date, ADD INDEX idx_crawl_date (crawl_date)"); err != nil {
+			return fmt.Errorf("迁移crawl_stats索引失败: %v", err)
+		}
+	}
+
+	exists, err = m.indexExists("crawl_stats", "uk_run_id")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if _, err := m.db.Exec("ALTER TABLE crawl_stats ADD UNIQUE KEY uk_run_id (run_id)"); err != nil {
+			return fmt.Errorf("创建run_id索引失败: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// SaveRun 保存抓取运行记录
+func (m *MySQLStorage) SaveRun(run *models.CrawlRun) error {
+	var reportedTotals interface{}
+	if len(run.Stats.ReportedTotals) > 0 {
+		data, err := json.Marshal(run.Stats.ReportedTotals)
+		if err != nil {
+			return fmt.Errorf("序列化报告总数失败: %v", err)
+		}
+		reportedTotals = string(data)
+	}
+
+	_, err := m.db.Exec(`
+	INSERT INTO crawl_stats (run_id, config_hash, crawl_date, range_start, range_end, status,
+		total_tasks, articles_count, success_count, failed_count, quarantined_count,
+		skipped_count, start_time, end_time, duration_seconds, reported_totals)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		status = VALUES(status),
+		total_tasks = VALUES(total_tasks),
+		articles_count = VALUES(articles_count),
+		success_count = VALUES(success_count),
+		failed_count = VALUES(failed_count),
+		quarantined_count = VALUES(quarantined_count),
+		skipped_count = VALUES(skipped_count),
+		end_time = VALUES(end_time),
+		duration_seconds = VALUES(duration_seconds),
+		reported_totals = VALUES(reported_totals)
+	`,
+		run.ID,
+		run.ConfigHash,
+		run.StartTime.Format("2006-01-02"),
+		nullableDate(run.RangeStart),
+		nullableDate(run.RangeEnd),
+		run.Status,
+		run.Stats.TotalTasks,
+		run.Stats.TotalArticles,
+		run.Stats.CompletedTasks,
+		run.Stats.FailedTasks,
+		run.Stats.Quarantined,
+		run.Stats.Skipped,
+		run.StartTime,
+		run.EndTime,
+		int(run.Stats.Duration.Seconds()),
+		reportedTotals,
+	)
+	if err != nil {
+		return fmt.Errorf("保存运行记录失败 [%s]: %v", run.ID, err)
+	}
+	return nil
+}
+
+// ListRuns 按开始时间倒序列出运行记录
+func (m *MySQLStorage) ListRuns(limit int) ([]*models.CrawlRun, error) {
+	query := runSelectSQL + " WHERE run_id IS NOT NULL ORDER BY start_time DESC"
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("查询运行记录失败: %v", err)
+	}
+	defer rows.Close()
+
+	var runs []*models.CrawlRun
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// GetRun 根据运行ID获取运行记录
+func (m *MySQLStorage) GetRun(id string) (*models.CrawlRun, error) {
+	row := m.db.QueryRow(runSelectSQL+" WHERE run_id = ?", id)
+	run, err := scanRun(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("运行记录不存在: %s", id)
+	}
+	return run, err
+}
+
+const runSelectSQL = `
+	SELECT run_id, config_hash, range_start, range_end, status, total_tasks,
+		articles_count, success_count, failed_count, quarantined_count,
+		skipped_count, start_time, end_time, duration_seconds, reported_totals
+	FROM crawl_stats`
+
+// rowScanner 兼容sql.Row和sql.Rows的扫描接口
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRun 将一行crawl_stats记录转换为运行记录
+func scanRun(row rowScanner) (*models.CrawlRun, error) {
+	var (
+		run                        models.CrawlRun
+		configHash, status         sql.NullString
+		reportedTotals             sql.NullString
+		rangeStart, rangeEnd       sql.NullTime
+		startTime, endTime         sql.NullTime
+		durationSeconds, totalTask sql.NullInt64
+		quarantined, skipped       sql.NullInt64
+	)
+
+	err := row.Scan(&run.ID, &configHash, &rangeStart, &rangeEnd, &status, &totalTask,
+		&run.Stats.TotalArticles, &run.Stats.CompletedTasks, &run.Stats.FailedTasks, &quarantined,
+		&skipped, &startTime, &endTime, &durationSeconds, &reportedTotals)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("读取运行记录失败: %v", err)
+	}
+
+	run.ConfigHash = configHash.String
+	run.Status = status.String
+	if rangeStart.Valid {
+		run.RangeStart = rangeStart.Time.Format("2006-01-02")
+	}
+	if rangeEnd.Valid {
+		run.RangeEnd = rangeEnd.Time.Format("2006-01-02")
+	}
+	run.StartTime = startTime.Time
+	run.EndTime = endTime.Time
+	run.Stats.TotalTasks = int(totalTask.Int64)
+	run.Stats.Quarantined = int(quarantined.Int64)
+	run.Stats.Skipped = int(skipped.Int64)
+	run.Stats.StartTime = startTime.Time
+	run.Stats.Duration = time.Duration(durationSeconds.Int64) * time.Second
+	if reportedTotals.Valid && reportedTotals.String != "" {
+		if err := json.Unmarshal([]byte(reportedTotals.String), &run.Stats.ReportedTotals); err != nil {
+			return nil, fmt.Errorf("解析报告总数失败 [%s]: %v", run.ID, err)
+		}
+	}
+	if run.Stats.Duration > 0 {
+		run.Stats.ArticlesPerSec = float64(run.Stats.TotalArticles) / run.Stats.Duration.Seconds()
+	}
+
+	return &run, nil
+}
+
+// nullableString 空字符串写入为NULL
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// nullableInt 零值整数写入为NULL
+func nullableInt(value int) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
+
+// nullableID 为0的规范ID写入为NULL
+func nullableID(value int64) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
+
+// nullableTime 零值时间写入为NULL
+func nullableTime(value time.Time) interface{} {
+	if value.IsZero() {
+		return nil
+	}
+	return value
+}
+
+// nullableDate 空日期字符串写入为NULL
+func nullableDate(date string) interface{} {
+	if date == "" {
+		return nil
+	}
+	return date
+}
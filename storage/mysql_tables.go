@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// createTablesTables 创建文章表格表和单元格表
+func (m *MySQLStorage) createTablesTables() error {
+	tablesSQL := `
+	CREATE TABLE IF NOT EXISTS article_tables (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		article_id BIGINT NOT NULL,
+		article_url VARCHAR(1000) NOT NULL,
+		position INT NOT NULL,
+		caption VARCHAR(500),
+		column_count INT NOT NULL,
+		row_count INT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX idx_article_id (article_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	if _, err := m.db.Exec(tablesSQL); err != nil {
+		return err
+	}
+
+	cellsSQL := `
+	CREATE TABLE IF NOT EXISTS article_table_cells (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		table_id BIGINT NOT NULL,
+		row_index INT NOT NULL,
+		column_index INT NOT NULL,
+		is_header TINYINT(1) NOT NULL DEFAULT 0,
+		value TEXT,
+		INDEX idx_table_cell (table_id, row_index, column_index)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	_, err := m.db.Exec(cellsSQL)
+	return err
+}
+
+// saveTables 在同一事务中用批次内文章的表格替换已存储的表格
+// 表头是第0行，数据行从第1行开始
+func (m *MySQLStorage) saveTables(tx *sql.Tx, articles []*models.Article) error {
+	for _, article := range articles {
+		_, err := tx.Exec(`
+		DELETE c FROM article_table_cells c JOIN article_tables t ON c.table_id = t.id
+		WHERE t.article_id = ?`, article.ID)
+		if err != nil {
+			return fmt.Errorf("删除表格单元格失败 [%s]: %v", article.URL, err)
+		}
+		if _, err := tx.Exec("DELETE FROM article_tables WHERE article_id = ?", article.ID); err != nil {
+			return fmt.Errorf("删除文章表格失败 [%s]: %v", article.URL, err)
+		}
+
+		for position, table := range article.Tables {
+			result, err := tx.Exec(`
+			INSERT INTO article_tables (article_id, article_url, position, caption, column_count, row_count)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+				article.ID, article.URL, position, nullableString(table.Caption), len(table.Header), len(table.Rows))
+			if err != nil {
+				return fmt.Errorf("插入文章表格失败 [%s]: %v", article.URL, err)
+			}
+			tableID, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("获取表格ID失败: %v", err)
+			}
+			if err := insertTableCells(tx, tableID, table); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// insertTableCells 插入表格的全部单元格
+func insertTableCells(tx *sql.Tx, tableID int64, table models.ArticleTable) error {
+	stmt, err := tx.Prepare(`
+	INSERT INTO article_table_cells (table_id, row_index, column_index, is_header, value)
+	VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("预编译插入单元格语句失败: %v", err)
+	}
+	defer stmt.Close()
+
+	rows := append([][]string{table.Header}, table.Rows...)
+	for i, row := range rows {
+		for j, value := range row {
+			if _, err := stmt.Exec(tableID, i, j, i == 0, value); err != nil {
+				return fmt.Errorf("插入表格单元格失败: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetTables 按出现顺序返回规范ID对应文章的表格
+func (m *MySQLStorage) GetTables(id int64) ([]models.ArticleTable, error) {
+	rows, err := m.db.Query(`
+	SELECT t.id, t.caption, t.column_count, t.row_count, c.row_index, c.column_index, c.value
+	FROM article_tables t LEFT JOIN article_table_cells c ON c.table_id = t.id
+	WHERE t.article_id = ?
+	ORDER BY t.position, c.row_index, c.column_index`, id)
+	if err != nil {
+		return nil, fmt.Errorf("查询文章表格失败: %v", err)
+	}
+	defer rows.Close()
+
+	var (
+		tables  []models.ArticleTable
+		current int64 = -1
+	)
+	for rows.Next() {
+		var (
+			id                    int64
+			caption, value        sql.NullString
+			columnCount, rowCount int
+			rowIndex, columnIndex sql.NullInt64
+		)
+		if err := rows.Scan(&id, &caption, &columnCount, &rowCount, &rowIndex, &columnIndex, &value); err != nil {
+			return nil, fmt.Errorf("读取文章表格失败: %v", err)
+		}
+
+		if id != current {
+			current = id
+			table := models.ArticleTable{
+				Caption: caption.String,
+				Header:  make([]string, columnCount),
+				Rows:    make([][]string, rowCount),
+			}
+			for i := range table.Rows {
+				table.Rows[i] = make([]string, columnCount)
+			}
+			tables = append(tables, table)
+		}
+
+		table := &tables[len(tables)-1]
+		row, column := int(rowIndex.Int64), int(columnIndex.Int64)
+		if !rowIndex.Valid || column >= columnCount || row > rowCount {
+			continue
+		}
+		if row == 0 {
+			table.Header[column] = value.String
+		} else {
+			table.Rows[row-1][column] = value.String
+		}
+	}
+
+	return tables, rows.Err()
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// QuarantineStorage 隔离区存储，按抓取时间段的月份写入JSON Lines文件
+// 被隔离文章的发布日期往往不可信，所以按抓取时间段而不是发布日期分文件
+type QuarantineStorage struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewQuarantineStorage 创建隔离区存储
+func NewQuarantineStorage(dir string) *QuarantineStorage {
+	return &QuarantineStorage{dir: dir}
+}
+
+// Init 创建隔离区目录
+func (q *QuarantineStorage) Init() error {
+	if err := os.MkdirAll(q.dir, 0755); err != nil {
+		return fmt.Errorf("创建隔离区目录失败: %v", err)
+	}
+	return nil
+}
+
+// SaveBatch 批量写入被隔离的文章
+func (q *QuarantineStorage) SaveBatch(items []*models.QuarantinedArticle) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	monthlyGroups := make(map[string][]*models.QuarantinedArticle)
+	for _, item := range items {
+		monthKey := strings.ReplaceAll(item.WindowStart, "-", "")
+		if len(monthKey) >= 6 {
+			monthKey = monthKey[:6]
+		}
+		monthlyGroups[monthKey] = append(monthlyGroups[monthKey], item)
+	}
+
+	for monthKey, monthItems := range monthlyGroups {
+		filename := filepath.Join(q.dir, fmt.Sprintf("quarantine_%s.jsonl", monthKey))
+		if err := appendJSONLines(filename, monthItems); err != nil {
+			return fmt.Errorf("写入隔离文件失败 [%s]: %v", monthKey, err)
+		}
+	}
+
+	return nil
+}
+
+// appendJSONLines 以JSON Lines格式追加写入
+func appendJSONLines(filename string, items []*models.QuarantinedArticle) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetEscapeHTML(false)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
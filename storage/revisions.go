@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// storedArticle CSV存储中已有文章的位置和指纹，用于重新抓取时的变化检测
+type storedArticle struct {
+	monthKey    string
+	contentHash string
+	title       string
+	createdAt   time.Time
+}
+
+// articleChanged 判断重新抓取的文章与已存储版本相比标题或正文是否变化
+func articleChanged(oldHash, oldTitle string, article *models.Article) bool {
+	return oldHash != article.ContentHash || oldTitle != article.Title
+}
+
+// revisionsPath 历史版本文件路径
+func (c *CSVStorage) revisionsPath() string {
+	return filepath.Join(c.outputDir, c.filePrefix+"_revisions.jsonl")
+}
+
+// detectRevisions 与已存储版本比较，返回需要写入的文章
+// 内容未变化的文章不再重复写入；内容变化的文章将旧版本归档到历史版本文件，并从原月份文件中移除
+// 新版本没有版面位置时（search方式抓取）沿用旧版本按版面抓取时记录的顺序和坐标
+// 同一批次中重复出现的文章只保留最后一个，避免写出规范ID相同的多行
+func (c *CSVStorage) detectRevisions(articles []*models.Article) ([]*models.Article, error) {
+	latest := make(map[int64]int, len(articles))
+	for i, article := range articles {
+		latest[article.ID] = i
+	}
+
+	changed := make(map[string]map[int64]*models.Article) // monthKey -> 需要移除的文章ID及其新版本
+	kept := make([]*models.Article, 0, len(articles))
+	for i, article := range articles {
+		if latest[article.ID] != i {
+			continue
+		}
+		stored, exists := c.stored[article.ID]
+		if exists && !articleChanged(stored.contentHash, stored.title, article) {
+			continue
+		}
+		if exists {
+			if changed[stored.monthKey] == nil {
+				changed[stored.monthKey] = make(map[int64]*models.Article)
+			}
+			changed[stored.monthKey][article.ID] = article
+		}
+		kept = append(kept, article)
+	}
+
+	now := time.Now()
+	for monthKey, ids := range changed {
+		c.closeMonth(monthKey)
+
+		monthArticles, err := c.readMonth(monthKey)
+		if err != nil {
+			return nil, err
+		}
+
+		var remaining []*models.Article
+		var revisions []*models.Revision
+		for _, article := range monthArticles {
+			if next, ok := ids[article.ID]; ok {
+				if next.Ordinal == 0 {
+					next.Ordinal, next.PageCoords = article.Ordinal, article.PageCoords
+				}
+				revisions = append(revisions, &models.Revision{Article: *article, ArchivedAt: now})
+				continue
+			}
+			remaining = append(remaining, article)
+		}
+
+		if err := c.appendRevisions(revisions); err != nil {
+			return nil, fmt.Errorf("写入历史版本失败: %v", err)
+		}
+		if err := c.rewriteMonth(monthKey, remaining); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, article := range kept {
+		c.stored[article.ID] = storedArticle{
+			monthKey:    article.PublishDate.Format("200601"),
+			contentHash: article.ContentHash,
+			title:       article.Title,
+			createdAt:   article.CreatedAt,
+		}
+	}
+	return kept, nil
+}
+
+// closeMonth 关闭月份文件的写入器，重写文件前调用，下次写入时重新打开
+func (c *CSVStorage) closeMonth(monthKey string) {
+	if writer, exists := c.writers[monthKey]; exists {
+		writer.Flush()
+		delete(c.writers, monthKey)
+	}
+	if file, exists := c.files[monthKey]; exists {
+		file.Close()
+		delete(c.files, monthKey)
+	}
+}
+
+// appendRevisions 追加写入历史版本
+func (c *CSVStorage) appendRevisions(revisions []*models.Revision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(c.revisionsPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetEscapeHTML(false)
+	for _, revision := range revisions {
+		if err := encoder.Encode(revision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRevisions 从历史版本文件中读取规范ID对应文章的历史版本
+func (c *CSVStorage) GetRevisions(id int64) ([]*models.Revision, error) {
+	file, err := os.Open(c.revisionsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开历史版本文件失败: %v", err)
+	}
+	defer file.Close()
+
+	var revisions []*models.Revision
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var revision models.Revision
+		if err := json.Unmarshal(scanner.Bytes(), &revision); err != nil {
+			return nil, fmt.Errorf("解析历史版本失败: %v", err)
+		}
+		// 早期版本归档的记录没有规范ID
+		revision.Article.EnsureID()
+		if revision.Article.ID == id {
+			revisions = append(revisions, &revision)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取历史版本文件失败: %v", err)
+	}
+
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].ArchivedAt.Before(revisions[j].ArchivedAt)
+	})
+	return revisions, nil
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// RunLog 本地运行日志（JSON Lines格式），用于没有MySQL的部署记录抓取历史
+type RunLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewRunLog 创建本地运行日志
+func NewRunLog(path string) *RunLog {
+	return &RunLog{path: path}
+}
+
+// SaveRun 追加一条运行记录，相同ID的后一条记录覆盖前一条
+func (r *RunLog) SaveRun(run *models.CrawlRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("创建运行日志目录失败: %v", err)
+	}
+
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("序列化运行记录失败: %v", err)
+	}
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("打开运行日志失败: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("写入运行日志失败: %v", err)
+	}
+	return nil
+}
+
+// ListRuns 按开始时间倒序列出运行记录
+func (r *RunLog) ListRuns(limit int) ([]*models.CrawlRun, error) {
+	runs, err := r.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartTime.After(runs[j].StartTime)
+	})
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// GetRun 根据运行ID获取运行记录
+func (r *RunLog) GetRun(id string) (*models.CrawlRun, error) {
+	runs, err := r.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, run := range runs {
+		if run.ID == id {
+			return run, nil
+		}
+	}
+	return nil, fmt.Errorf("运行记录不存在: %s", id)
+}
+
+// readAll 读取全部运行记录，相同ID只保留最后一条
+func (r *RunLog) readAll() ([]*models.CrawlRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := os.Open(r.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开运行日志失败: %v", err)
+	}
+	defer file.Close()
+
+	var runs []*models.CrawlRun
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var run models.CrawlRun
+		if err := json.Unmarshal(line, &run); err != nil {
+			return nil, fmt.Errorf("解析运行日志失败: %v", err)
+		}
+
+		if i, exists := index[run.ID]; exists {
+			runs[i] = &run
+		} else {
+			index[run.ID] = len(runs)
+			runs = append(runs, &run)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取运行日志失败: %v", err)
+	}
+
+	return runs, nil
+}
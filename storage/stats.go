@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// CollectStats 通过遍历文章计算语料统计，适用于不支持聚合查询的存储
+func CollectStats(reader Reader, filter ArticleFilter) (*models.CorpusStats, error) {
+	filter.After = nil
+	filter.Limit = 0
+
+	var (
+		stats         models.CorpusStats
+		contentLength int
+		byYear        = make(map[string]int)
+		byMonth       = make(map[string]int)
+		byEdition     = make(map[string]int)
+		byType        = make(map[string]int)
+	)
+
+	err := reader.ForEach(filter, func(article *models.Article) error {
+		stats.TotalArticles++
+		contentLength += utf8.RuneCountInString(article.Content)
+
+		date := article.PublishDate.Format("2006-01-02")
+		if stats.EarliestDate == "" || date < stats.EarliestDate {
+			stats.EarliestDate = date
+		}
+		if date > stats.LatestDate {
+			stats.LatestDate = date
+		}
+
+		byYear[article.PublishDate.Format("2006")]++
+		byMonth[article.PublishDate.Format("2006-01")]++
+		byEdition[article.Edition]++
+		byType[article.Type]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.TotalArticles > 0 {
+		stats.AvgContentLength = float64(contentLength) / float64(stats.TotalArticles)
+	}
+	stats.ByYear = sortedCounts(byYear)
+	stats.ByMonth = sortedCounts(byMonth)
+	stats.ByEdition = sortedEditionCounts(byEdition)
+	stats.ByType = sortedCounts(byType)
+
+	return &stats, nil
+}
+
+// GetStats 统计CSV中的文章
+func (c *CSVStorage) GetStats(filter ArticleFilter) (*models.CorpusStats, error) {
+	return CollectStats(c, filter)
+}
+
+// sortedCounts 将计数表转换为按键升序的列表
+func sortedCounts(counts map[string]int) []models.StatCount {
+	result := make([]models.StatCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, models.StatCount{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// toCountMap 将计数列表转换为计数表
+func toCountMap(counts []models.StatCount) map[string]int {
+	result := make(map[string]int, len(counts))
+	for _, count := range counts {
+		result[count.Key] += count.Count
+	}
+	return result
+}
+
+// editionNumberRe 提取版次中的数字
+var editionNumberRe = regexp.MustCompile(`\d+`)
+
+// sortedEditionCounts 版次按数字大小排序，无法识别的版次排在最后
+func sortedEditionCounts(counts map[string]int) []models.StatCount {
+	result := sortedCounts(counts)
+	number := func(edition string) int {
+		if n, err := strconv.Atoi(editionNumberRe.FindString(edition)); err == nil {
+			return n
+		}
+		return int(^uint(0) >> 1)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return number(result[i].Key) < number(result[j].Key)
+	})
+	return result
+}
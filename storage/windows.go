@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// WindowLog 本地时间段记录（JSON Lines格式），记录search方式每个抓取完的时间段中文章的规范ID
+// 同一时间段的后一条记录覆盖前一条
+type WindowLog struct {
+	path    string
+	mu      sync.Mutex
+	windows map[string]*models.SearchWindow // 第一次查询时加载
+}
+
+// NewWindowLog 创建时间段记录
+func NewWindowLog(path string) *WindowLog {
+	return &WindowLog{path: path}
+}
+
+// GetWindow 返回时间段的最近一条记录，没有时返回nil
+func (w *WindowLog) GetWindow(key string) (*models.SearchWindow, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+	return w.windows[key], nil
+}
+
+// SaveWindow 追加一条时间段记录
+func (w *WindowLog) SaveWindow(window *models.SearchWindow) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.load(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("创建时间段记录目录失败: %v", err)
+	}
+
+	data, err := json.Marshal(window)
+	if err != nil {
+		return fmt.Errorf("序列化时间段记录失败: %v", err)
+	}
+
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("打开时间段记录失败: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("写入时间段记录失败: %v", err)
+	}
+	w.windows[window.Key] = window
+	return nil
+}
+
+// load 读取全部记录，文件不存在时为空，无法解析的行（如写入中断的最后一行）跳过
+func (w *WindowLog) load() error {
+	if w.windows != nil {
+		return nil
+	}
+
+	windows := make(map[string]*models.SearchWindow)
+	file, err := os.Open(w.path)
+	if os.IsNotExist(err) {
+		w.windows = windows
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("打开时间段记录失败: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var window models.SearchWindow
+		if err := json.Unmarshal(scanner.Bytes(), &window); err != nil {
+			continue
+		}
+		windows[window.Key] = &window
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取时间段记录失败: %v", err)
+	}
+	w.windows = windows
+	return nil
+}
@@ -0,0 +1,95 @@
+package utils
+
+import "strings"
+
+// DiffOp 差异操作类型
+type DiffOp int
+
+const (
+	DiffEqual  DiffOp = iota // 两个版本相同
+	DiffDelete               // 仅旧版本有
+	DiffInsert               // 仅新版本有
+)
+
+// DiffLine 一段差异，Text为一个句子
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffText 按句子比较两段正文，返回基于最长公共子序列的差异
+// 正文在CSV中换行会被替换为空格，所以按中文句末标点和换行切分而不是按行
+func DiffText(oldText, newText string) []DiffLine {
+	a := SplitSentences(oldText)
+	b := SplitSentences(newText)
+
+	// lcs[i][j] 为 a[i:] 与 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []DiffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+
+	return lines
+}
+
+// SplitSentences 按句末标点（。！？；）和换行切分句子，标点保留在句尾
+func SplitSentences(text string) []string {
+	var sentences []string
+	var builder strings.Builder
+
+	flush := func() {
+		if sentence := strings.TrimSpace(builder.String()); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		builder.Reset()
+	}
+
+	for _, r := range text {
+		switch r {
+		case '\n', '\r':
+			flush()
+		case '。', '！', '？', '；':
+			builder.WriteRune(r)
+			flush()
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	flush()
+
+	return sentences
+}
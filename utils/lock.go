@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileLock 基于文件锁的进程互斥锁（Unix为flock，Windows为LockFileEx），
+// 进程退出（包括被强制结束）时由系统自动释放
+// 锁文件中记录持有锁的进程ID，仅用于提示
+type FileLock struct {
+	file *os.File
+}
+
+// TryLock 尝试获取锁，锁被其他进程持有时立即返回错误
+func TryLock(path string) (*FileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("创建锁文件目录失败: %v", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("打开锁文件失败: %v", err)
+	}
+
+	if busy, err := lockFile(file); err != nil {
+		file.Close()
+		if busy {
+			if pid := lockOwner(path); pid > 0 {
+				return nil, fmt.Errorf("已有抓取正在运行 (进程 %d，锁文件 %s)", pid, path)
+			}
+			return nil, fmt.Errorf("已有抓取正在运行 (锁文件 %s)", path)
+		}
+		return nil, fmt.Errorf("获取锁失败: %v", err)
+	}
+
+	// 持有锁之后再写入进程ID，其他进程读到的总是当前持有者
+	if err := file.Truncate(0); err == nil {
+		file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	return &FileLock{file: file}, nil
+}
+
+// Unlock 释放锁
+// 锁文件保留不删除：删除后其他进程可能锁住新建的文件，而等待中的进程仍锁着旧文件
+func (l *FileLock) Unlock() error {
+	l.file.Truncate(0)
+	if err := unlockFile(l.file); err != nil {
+		l.file.Close()
+		return fmt.Errorf("释放锁失败: %v", err)
+	}
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("关闭锁文件失败: %v", err)
+	}
+	return nil
+}
+
+// lockOwner 读取锁文件中的进程ID，无法读取时返回0
+func lockOwner(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
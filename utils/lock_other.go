@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package utils
+
+import "os"
+
+// lockFile 当前平台没有可用的文件锁，不做互斥，同一输出目录只应运行一个抓取
+func lockFile(file *os.File) (busy bool, err error) {
+	return false, nil
+}
+
+// unlockFile 释放文件锁
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package utils
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile 以非阻塞方式对文件加排他锁，busy表示锁被其他进程持有
+func lockFile(file *os.File) (busy bool, err error) {
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	return err == syscall.EWOULDBLOCK, err
+}
+
+// unlockFile 释放文件锁
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
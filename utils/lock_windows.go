@@ -0,0 +1,26 @@
+//go:build windows
+
+package utils
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockOffset 加锁的字节位置，放在文件内容之外，其他进程仍能读取锁文件中的进程ID
+const lockOffset = 0xFFFFFFFF
+
+// lockFile 以非阻塞方式对文件加排他锁，busy表示锁被其他进程持有
+func lockFile(file *os.File) (busy bool, err error) {
+	overlapped := &windows.Overlapped{OffsetHigh: lockOffset}
+	err = windows.LockFileEx(windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, overlapped)
+	return err == windows.ERROR_LOCK_VIOLATION, err
+}
+
+// unlockFile 释放文件锁
+func unlockFile(file *os.File) error {
+	overlapped := &windows.Overlapped{OffsetHigh: lockOffset}
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, overlapped)
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Lan-ce-lot/data-people/models"
+)
+
+// LoadPlan 读取补抓计划并转换为日期范围
+func LoadPlan(path string) ([]DateRange, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取补抓计划失败: %v", err)
+	}
+
+	var plan models.CrawlPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("解析补抓计划失败: %v", err)
+	}
+
+	var ranges []DateRange
+	for _, window := range plan.Windows {
+		start, err := time.Parse("2006-01-02", window.Start)
+		if err != nil {
+			return nil, fmt.Errorf("解析计划开始日期失败 [%s]: %v", window.Start, err)
+		}
+		end, err := time.Parse("2006-01-02", window.End)
+		if err != nil {
+			return nil, fmt.Errorf("解析计划结束日期失败 [%s]: %v", window.End, err)
+		}
+		if start.After(end) {
+			return nil, fmt.Errorf("计划时间段无效: %s 到 %s", window.Start, window.End)
+		}
+		ranges = append(ranges, DateRange{Start: start, End: end})
+	}
+
+	return ranges, nil
+}
+
+// SavePlan 将补抓计划写入文件
+func SavePlan(path string, plan *models.CrawlPlan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化补抓计划失败: %v", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Shard 静态分片，Index从1开始
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShard 解析 i/n 格式的分片，例如 1/3、2/3、3/3
+func ParseShard(value string) (Shard, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return Shard{}, fmt.Errorf("分片格式应为 i/n: %s", value)
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Shard{}, fmt.Errorf("无效的分片序号: %s", parts[0])
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Shard{}, fmt.Errorf("无效的分片数: %s", parts[1])
+	}
+	if count <= 0 {
+		return Shard{}, fmt.Errorf("分片数必须大于0: %s", value)
+	}
+	if index < 1 || index > count {
+		return Shard{}, fmt.Errorf("分片序号应在1到%d之间: %s", count, value)
+	}
+	return Shard{Index: index, Count: count}, nil
+}
+
+// String 返回 i/n 格式
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// Select 按计划中的顺序轮流分配时间段，返回属于本分片的时间段
+// 配置相同的各台机器生成的计划相同，各分片互不重叠且合起来覆盖全部时间段
+func (s Shard) Select(dateRanges []DateRange) []DateRange {
+	var selected []DateRange
+	for i, dateRange := range dateRanges {
+		if i%s.Count == s.Index-1 {
+			selected = append(selected, dateRange)
+		}
+	}
+	return selected
+}
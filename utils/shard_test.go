@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseShard(t *testing.T) {
+	cases := []struct {
+		value   string
+		want    Shard
+		wantErr bool
+	}{
+		{"1/3", Shard{Index: 1, Count: 3}, false},
+		{" 3 / 3 ", Shard{Index: 3, Count: 3}, false},
+		{"1/1", Shard{Index: 1, Count: 1}, false},
+		{"0/3", Shard{}, true},
+		{"4/3", Shard{}, true},
+		{"1/0", Shard{}, true},
+		{"1", Shard{}, true},
+		{"a/3", Shard{}, true},
+		{"1/2/3", Shard{}, true},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseShard(tc.value)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseShard(%q) 错误 = %v, 期望出错 %v", tc.value, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseShard(%q) = %v, 期望 %v", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestShardSelect(t *testing.T) {
+	cases := []struct {
+		name    string
+		windows int
+		count   int
+	}{
+		{"单个分片", 5, 1},
+		{"整除", 6, 3},
+		{"不整除", 7, 3},
+		{"分片多于时间段", 2, 4},
+		{"没有时间段", 0, 3},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dateRanges []DateRange
+			for i := 0; i < tc.windows; i++ {
+				day := start.AddDate(0, 0, i)
+				dateRanges = append(dateRanges, DateRange{Start: day, End: day})
+			}
+
+			// 各分片互不重叠，合起来覆盖全部时间段
+			owner := make(map[string]int)
+			for index := 1; index <= tc.count; index++ {
+				selected := Shard{Index: index, Count: tc.count}.Select(dateRanges)
+				if len(selected) > (tc.windows+tc.count-1)/tc.count {
+					t.Errorf("分片 %d/%d 分到 %d 个时间段，分配不均匀", index, tc.count, len(selected))
+				}
+				for _, dateRange := range selected {
+					if previous, exists := owner[dateRange.Key()]; exists {
+						t.Errorf("时间段 %s 同时属于分片 %d 和 %d", dateRange.Key(), previous, index)
+					}
+					owner[dateRange.Key()] = index
+				}
+			}
+			for _, dateRange := range dateRanges {
+				if _, exists := owner[dateRange.Key()]; !exists {
+					t.Errorf("时间段 %s 不属于任何分片", dateRange.Key())
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/Lan-ce-lot/data-people/models"
@@ -12,18 +13,31 @@ import (
 // URLBuilder URL构建器
 type URLBuilder struct {
 	baseSearchURL string
+	baseLayoutURL string
 }
 
 // NewURLBuilder 创建URL构建器
-func NewURLBuilder(baseSearchURL string) *URLBuilder {
+func NewURLBuilder(baseSearchURL, baseLayoutURL string) *URLBuilder {
 	if baseSearchURL == "" {
 		baseSearchURL = "https://data.people.com.cn/rmrb/pd.html" // 默认值
 	}
+	if baseLayoutURL == "" {
+		baseLayoutURL = "http://paper.people.com.cn/rmrb/pc/layout/" // 默认值
+	}
+	if !strings.HasSuffix(baseLayoutURL, "/") {
+		baseLayoutURL += "/"
+	}
 	return &URLBuilder{
 		baseSearchURL: baseSearchURL,
+		baseLayoutURL: baseLayoutURL,
 	}
 }
 
+// BuildLayoutURL 构建电子版版面URL，如 .../layout/202508/30/node_01.html
+func (u *URLBuilder) BuildLayoutURL(date time.Time, page int) string {
+	return fmt.Sprintf("%s%s/node_%02d.html", u.baseLayoutURL, date.Format("200601/02"), page)
+}
+
 // BuildSearchURL 构建搜索URL
 func (u *URLBuilder) BuildSearchURL(startDate, endDate time.Time, pageNo, position int) (string, error) {
 	// 构建查询条件
@@ -183,6 +197,21 @@ func (dr DateRange) String() string {
 		dr.End.Format("2006-01-02"))
 }
 
+// Days 返回日期范围内的每一天，不超过今天
+func (dr DateRange) Days() []time.Time {
+	var days []time.Time
+	now := time.Now()
+	for day := dr.Start; !day.After(dr.End) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// Key 返回日期范围的键值（YYYY-MM-DD~YYYY-MM-DD格式）
+func (dr DateRange) Key() string {
+	return dr.Start.Format("2006-01-02") + "~" + dr.End.Format("2006-01-02")
+}
+
 // GetMonthKey 获取月份键值（YYYYMM格式）
 func (dr DateRange) GetMonthKey() string {
 	return dr.Start.Format("200601")